@@ -0,0 +1,163 @@
+// Package anonymize redacts the personal data of users, so that SDK types
+// and traffic can be logged without exposing it, as GDPR requires.
+//
+// Emails are replaced with their SHA-256 hash, which still lets log entries
+// of the same user be correlated; names are replaced with their initial, and
+// phone numbers are masked but for their last two digits.
+package anonymize
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Email returns the SHA-256 hex hash of email, or "" if email is empty.
+func Email(email string) string {
+	if email == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+// Name returns the initial of name followed by a period, such as "J." for
+// "Jane", or "" if name is empty.
+func Name(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	r, _ := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + "."
+}
+
+// Phone returns phone with every digit but the last two replaced with *,
+// such as "+** ** **** **58" for "+44 20 7946 0958". Other characters are kept.
+func Phone(phone string) string {
+	digits := 0
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+
+	var b strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits--
+			if digits >= 2 {
+				r = '*'
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// User returns a copy of u that is safe to log: Email is hashed, and
+// FirstName and LastName are replaced with their initials. Fields without
+// personal data, such as Id and Role, are kept. It returns nil if u is nil.
+//
+// Parameters:
+// - u: The user to anonymize; it is left unchanged.
+//
+// Returns:
+// - User: The anonymized copy.
+//
+// Example usage:
+//
+//	output, err := usersClient.GetUser(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get user: %v", err)
+//	}
+//	log.Printf("Got user: %+v", anonymize.User(&output.User))
+func User(u *users.User) *users.User {
+	if u == nil {
+		return nil
+	}
+	anonymized := *u
+	anonymized.Email = Email(u.Email)
+	anonymized.FirstName = Name(u.FirstName)
+	anonymized.LastName = Name(u.LastName)
+	return &anonymized
+}
+
+// fields maps the JSON names of personal data fields to their anonymizer.
+var fields = map[string]func(string) string{
+	"email":        Email,
+	"first_name":   Name,
+	"last_name":    Name,
+	"contact":      Phone,
+	"phone_number": Phone,
+}
+
+// redactedBody replaces the bodies that cannot be anonymized.
+var redactedBody = []byte("[body redacted]")
+
+// Body returns a JSON body with the personal data fields of every object in
+// it anonymized. Bodies that are not valid JSON, including those cut short,
+// are replaced with a placeholder, so that raw data is never returned.
+func Body(body []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return redactedBody
+	}
+	b, err := json.Marshal(anonymizeValue(v))
+	if err != nil {
+		return redactedBody
+	}
+	return b
+}
+
+// anonymizeValue anonymizes the personal data fields of the objects in v.
+func anonymizeValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if s, ok := value.(string); ok {
+				if anonymize := fields[key]; anonymize != nil {
+					v[key] = anonymize(s)
+					continue
+				}
+			}
+			v[key] = anonymizeValue(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = anonymizeValue(value)
+		}
+	}
+	return v
+}
+
+// WithAnonymizedLogging makes the debug transport, see
+// superclouds.WithDebugWriter, anonymize the personal data in the request
+// and response bodies it writes when enabled is true, see Body, and redact
+// the query values and identity headers of the requests, see
+// superclouds.WithDebugIdentityRedaction. Passing false restores the raw
+// requests and bodies.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithDebugWriter(os.Stderr),
+//	    anonymize.WithAnonymizedLogging(true),
+//	)
+func WithAnonymizedLogging(enabled bool) superclouds.Option {
+	filter := superclouds.WithDebugBodyFilter(nil)
+	if enabled {
+		filter = superclouds.WithDebugBodyFilter(Body)
+	}
+	redaction := superclouds.WithDebugIdentityRedaction(enabled)
+	return func(c *superclouds.Config) {
+		filter(c)
+		redaction(c)
+	}
+}
@@ -0,0 +1,83 @@
+package anonymize_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/anonymize"
+	supercontext "github.com/superclouds/super-sdk-go-v1/superclouds/context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const (
+	userEmail  = "delete.user@example.com"
+	actorEmail = "admin@example.com"
+)
+
+// deleteUser calls DeleteUser with the debug output written to the returned
+// buffer, and returns it with the email the server received.
+func deleteUser(t *testing.T, opts ...superclouds.Option) (dump, received string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.URL.Query().Get("email")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	opts = append([]superclouds.Option{
+		superclouds.WithBaseURL(srv.URL),
+		superclouds.WithToken("test-token"),
+		superclouds.WithHTTPClient(srv.Client()),
+		superclouds.WithDebugWriter(&buf),
+	}, opts...)
+	cfg, err := superclouds.NewConfigWithOptions("", "", opts...)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	ctx := supercontext.WithRequestContext(context.Background(), &supercontext.RequestContext{ActorEmail: actorEmail})
+	if err := users.NewUsersClient(cfg).DeleteUser(ctx, &users.DeleteUserInput{Email: userEmail}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	return buf.String(), received
+}
+
+func TestAnonymizedLoggingRedactsEmails(t *testing.T) {
+	dump, received := deleteUser(t, anonymize.WithAnonymizedLogging(true))
+
+	for _, email := range []string{userEmail, url.QueryEscape(userEmail), actorEmail} {
+		if strings.Contains(dump, email) {
+			t.Errorf("debug output contains %q:\n%s", email, dump)
+		}
+	}
+	if !strings.Contains(dump, "DELETE /users?email=[REDACTED]") {
+		t.Errorf("debug output has no redacted request line:\n%s", dump)
+	}
+	if received != userEmail {
+		t.Errorf("email received by the server = %q, want %q", received, userEmail)
+	}
+}
+
+func TestLoggingWithoutAnonymization(t *testing.T) {
+	dump, _ := deleteUser(t, anonymize.WithAnonymizedLogging(false))
+
+	if !strings.Contains(dump, url.QueryEscape(userEmail)) || !strings.Contains(dump, actorEmail) {
+		t.Errorf("debug output without anonymization is missing the emails:\n%s", dump)
+	}
+}
+
+func TestBody(t *testing.T) {
+	got := string(anonymize.Body([]byte(`{"data":{"email":"jane@example.com","first_name":"jane","contact":"+44 20 7946 0958"}}`)))
+	if strings.Contains(got, "jane@example.com") || !strings.Contains(got, `"first_name":"J."`) || !strings.Contains(got, `"contact":"+** ** **** **58"`) {
+		t.Errorf("Body = %s", got)
+	}
+	if got := string(anonymize.Body([]byte(`{"email":"jane@exa`))); got != "[body redacted]" {
+		t.Errorf("Body of invalid JSON = %q, want %q", got, "[body redacted]")
+	}
+}
@@ -0,0 +1,160 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"net/url"
+	"time"
+)
+
+// Client provides methods to manage approval workflows for sensitive operations through the Superclouds API.
+type Client struct {
+	config *superclouds.Config
+}
+
+// NewClient creates a new approval Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	approvalClient := approval.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg}
+}
+
+// Operations that can require approval, used in the Operation field of ApprovalRequest.
+const (
+	OperationDeleteUser     = "delete_user"
+	OperationUpdateUserRole = "update_user_role"
+)
+
+// Approval states reported in the Status field of Approval.
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// ApprovalRequest defines the input parameters for the RequestApproval method.
+// Resource identifies the target of the operation, such as the email of the user to delete.
+type ApprovalRequest struct {
+	Operation string `json:"operation"`
+	Resource  string `json:"resource"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Approval describes an approval request and its decision.
+//
+// Once Status is StatusApproved, ID can be passed as the ApprovalID of the
+// matching SDK method input, for example users.DeleteUserInput.
+type Approval struct {
+	ID          string     `json:"id"`
+	Operation   string     `json:"operation"`
+	Resource    string     `json:"resource"`
+	Status      string     `json:"status"`
+	RequestedBy string     `json:"requested_by"`
+	DecidedBy   string     `json:"decided_by"`
+	Reason      string     `json:"reason"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DecidedAt   *time.Time `json:"decided_at"`
+}
+
+// RequestApproval asks a second admin to approve a sensitive operation.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The operation to approve.
+//
+// Returns:
+// - Approval: The pending approval.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	pending, err := approvalClient.RequestApproval(context.TODO(), &approval.ApprovalRequest{
+//	    Operation: approval.OperationDeleteUser,
+//	    Resource:  "delete.user@example.com",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to request approval: %v", err)
+//	}
+//	log.Printf("Approval: %v", pending)
+func (c *Client) RequestApproval(ctx context.Context, input *ApprovalRequest) (*Approval, error) {
+	if input == nil || input.Operation == "" || input.Resource == "" {
+		return nil, fmt.Errorf("operation and resource are required")
+	}
+
+	apiResponse, err := generic.Post[ApprovalRequest, generic.Response[Approval]](ctx, c.config, "/approvals", input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request approval: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// ApproveRequest approves a pending approval request.
+//
+// Example usage:
+//
+//	err := approvalClient.ApproveRequest(context.TODO(), "approval-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to approve request: %v", err)
+//	}
+func (c *Client) ApproveRequest(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("approval ID is required")
+	}
+
+	if _, err := generic.Post[struct{}, struct{}](ctx, c.config, "/approvals/"+url.PathEscape(id)+"/approve", nil); err != nil {
+		return fmt.Errorf("failed to approve request: %w", err)
+	}
+
+	return nil
+}
+
+// RejectRequest rejects a pending approval request with the given reason.
+//
+// Example usage:
+//
+//	err := approvalClient.RejectRequest(context.TODO(), "approval-id", "user still owns active projects")
+//	if err != nil {
+//	    log.Fatalf("Failed to reject request: %v", err)
+//	}
+func (c *Client) RejectRequest(ctx context.Context, id string, reason string) error {
+	if id == "" {
+		return fmt.Errorf("approval ID is required")
+	}
+
+	body := map[string]string{"reason": reason}
+	if _, err := generic.Post[map[string]string, struct{}](ctx, c.config, "/approvals/"+url.PathEscape(id)+"/reject", &body); err != nil {
+		return fmt.Errorf("failed to reject request: %w", err)
+	}
+
+	return nil
+}
+
+// GetApprovalStatus retrieves an approval request and its current status.
+//
+// Example usage:
+//
+//	status, err := approvalClient.GetApprovalStatus(context.TODO(), "approval-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to get approval status: %v", err)
+//	}
+//	log.Printf("Approval Status: %v", status.Status)
+func (c *Client) GetApprovalStatus(ctx context.Context, id string) (*Approval, error) {
+	if id == "" {
+		return nil, fmt.Errorf("approval ID is required")
+	}
+
+	apiResponse, err := generic.Get[generic.Response[Approval]](ctx, c.config, "/approvals/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval status: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
@@ -0,0 +1,200 @@
+// Package avro serialises users and user change events in Apache Avro binary format,
+// for data pipelines that carry them through Kafka with a schema registry.
+package avro
+
+import (
+	"fmt"
+	"github.com/linkedin/goavro/v2"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"time"
+)
+
+// UserSchema is the Avro schema of a users.User.
+const UserSchema = `{
+	"type": "record",
+	"name": "User",
+	"namespace": "ooo.superclouds.users",
+	"fields": [
+		{"name": "id", "type": "string"},
+		{"name": "email", "type": "string"},
+		{"name": "first_name", "type": "string"},
+		{"name": "last_name", "type": "string"},
+		{"name": "role", "type": "long"},
+		{"name": "status", "type": "string"},
+		{"name": "created_at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "updated_at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "last_login_at", "type": ["null", {"type": "long", "logicalType": "timestamp-millis"}], "default": null}
+	]
+}`
+
+// UserChangeEventSchema is the Avro schema of a UserChangeEvent. It embeds UserSchema.
+const UserChangeEventSchema = `{
+	"type": "record",
+	"name": "UserChangeEvent",
+	"namespace": "ooo.superclouds.users",
+	"fields": [
+		{"name": "type", "type": {"type": "enum", "name": "ChangeType", "symbols": ["CREATED", "UPDATED", "DELETED", "ROLE_CHANGED"]}},
+		{"name": "email", "type": "string"},
+		{"name": "user", "type": ["null", ` + UserSchema + `], "default": null},
+		{"name": "occurred_at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "old_role", "type": ["null", "string"], "default": null},
+		{"name": "new_role", "type": ["null", "string"], "default": null}
+	]
+}`
+
+// Change types of a UserChangeEvent.
+const (
+	ChangeCreated     = "CREATED"
+	ChangeUpdated     = "UPDATED"
+	ChangeDeleted     = "DELETED"
+	ChangeRoleChanged = "ROLE_CHANGED"
+)
+
+// UserChangeEvent records a change made to a user.
+// User is nil for events that do not carry the user record, such as deletions.
+// OldRole and NewRole are only set on ChangeRoleChanged events.
+// The JSON encoding of an event is used by publishers with JSON payloads.
+type UserChangeEvent struct {
+	Type       string      `json:"type"`
+	Email      string      `json:"email"`
+	User       *users.User `json:"user,omitempty"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	OldRole    string      `json:"old_role,omitempty"`
+	NewRole    string      `json:"new_role,omitempty"`
+}
+
+var (
+	userCodec            = mustCodec(UserSchema)
+	userChangeEventCodec = mustCodec(UserChangeEventSchema)
+)
+
+func mustCodec(schema string) *goavro.Codec {
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		panic(fmt.Sprintf("invalid Avro schema: %v", err))
+	}
+	return codec
+}
+
+// MarshalUser encodes u in Avro binary format, following UserSchema.
+//
+// Example usage:
+//
+//	data, err := avro.MarshalUser(&user)
+//	if err != nil {
+//	    log.Fatalf("Failed to marshal user: %v", err)
+//	}
+func MarshalUser(u *users.User) ([]byte, error) {
+	data, err := userCodec.BinaryFromNative(nil, userToNative(u))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalUser decodes a user encoded by MarshalUser.
+//
+// Example usage:
+//
+//	user, err := avro.UnmarshalUser(data)
+//	if err != nil {
+//	    log.Fatalf("Failed to unmarshal user: %v", err)
+//	}
+func UnmarshalUser(data []byte) (*users.User, error) {
+	native, _, err := userCodec.NativeFromBinary(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+	}
+	return userFromNative(native.(map[string]interface{})), nil
+}
+
+// MarshalUserChangeEvent encodes e in Avro binary format, following UserChangeEventSchema.
+func MarshalUserChangeEvent(e *UserChangeEvent) ([]byte, error) {
+	var user interface{}
+	if e.User != nil {
+		user = goavro.Union("ooo.superclouds.users.User", userToNative(e.User))
+	}
+	data, err := userChangeEventCodec.BinaryFromNative(nil, map[string]interface{}{
+		"type":        e.Type,
+		"email":       e.Email,
+		"user":        user,
+		"occurred_at": e.OccurredAt,
+		"old_role":    optionalString(e.OldRole),
+		"new_role":    optionalString(e.NewRole),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user change event: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalUserChangeEvent decodes an event encoded by MarshalUserChangeEvent.
+func UnmarshalUserChangeEvent(data []byte) (*UserChangeEvent, error) {
+	native, _, err := userChangeEventCodec.NativeFromBinary(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user change event: %w", err)
+	}
+
+	record := native.(map[string]interface{})
+	event := &UserChangeEvent{
+		Type:       record["type"].(string),
+		Email:      record["email"].(string),
+		OccurredAt: record["occurred_at"].(time.Time),
+	}
+	if user, ok := record["user"].(map[string]interface{}); ok {
+		event.User = userFromNative(user["ooo.superclouds.users.User"].(map[string]interface{}))
+	}
+	if oldRole, ok := record["old_role"].(map[string]interface{}); ok {
+		event.OldRole = oldRole["string"].(string)
+	}
+	if newRole, ok := record["new_role"].(map[string]interface{}); ok {
+		event.NewRole = newRole["string"].(string)
+	}
+	return event, nil
+}
+
+// optionalString returns the goavro native form of an optional string, null when s is empty.
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return goavro.Union("string", s)
+}
+
+// userToNative converts u to the goavro native form of UserSchema.
+func userToNative(u *users.User) map[string]interface{} {
+	var lastLoginAt interface{}
+	if u.LastLoginAt != nil {
+		lastLoginAt = goavro.Union("long.timestamp-millis", *u.LastLoginAt)
+	}
+	return map[string]interface{}{
+		"id":            u.Id,
+		"email":         u.Email,
+		"first_name":    u.FirstName,
+		"last_name":     u.LastName,
+		"role":          int64(u.Role),
+		"status":        u.Status,
+		"created_at":    u.CreatedAt,
+		"updated_at":    u.UpdatedAt,
+		"last_login_at": lastLoginAt,
+	}
+}
+
+// userFromNative converts the goavro native form of UserSchema to a user.
+func userFromNative(record map[string]interface{}) *users.User {
+	u := &users.User{
+		Id:        record["id"].(string),
+		Email:     record["email"].(string),
+		FirstName: record["first_name"].(string),
+		LastName:  record["last_name"].(string),
+		Role:      users.Role(record["role"].(int64)),
+		Status:    record["status"].(string),
+		CreatedAt: record["created_at"].(time.Time),
+		UpdatedAt: record["updated_at"].(time.Time),
+	}
+	if lastLoginAt, ok := record["last_login_at"].(map[string]interface{}); ok {
+		t := lastLoginAt["long.timestamp-millis"].(time.Time)
+		u.LastLoginAt = &t
+	}
+	return u
+}
@@ -0,0 +1,114 @@
+package avro
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// magicByte starts every message in the schema registry wire format.
+const magicByte = 0
+
+// headerSize is the size of the schema ID header: the magic byte and a 4-byte big-endian schema ID.
+const headerSize = 5
+
+// WithSchemaID prefixes data with the schema ID header of the schema registry
+// wire format, so that consumers can look up the schema it was written with.
+func WithSchemaID(schemaID int, data []byte) []byte {
+	msg := make([]byte, headerSize, headerSize+len(data))
+	msg[0] = magicByte
+	binary.BigEndian.PutUint32(msg[1:headerSize], uint32(schemaID))
+	return append(msg, data...)
+}
+
+// SplitSchemaID returns the schema ID and the Avro data of a message written by WithSchemaID.
+func SplitSchemaID(msg []byte) (schemaID int, data []byte, err error) {
+	if len(msg) < headerSize || msg[0] != magicByte {
+		return 0, nil, fmt.Errorf("message has no schema ID header")
+	}
+	return int(binary.BigEndian.Uint32(msg[1:headerSize])), msg[headerSize:], nil
+}
+
+// RegistryClient registers schemas with a Confluent-compatible schema registry.
+// Registered schema IDs are cached, so each schema is registered once per client.
+type RegistryClient struct {
+	baseURL string
+	client  *http.Client
+
+	mu  sync.Mutex
+	ids map[string]int
+}
+
+// NewRegistryClient creates a RegistryClient for the schema registry at baseURL.
+// If client is nil, http.DefaultClient is used.
+//
+// Example usage:
+//
+//	registry := avro.NewRegistryClient("http://schema-registry:8081", nil)
+//	schemaID, err := registry.Register(ctx, "users-value", avro.UserSchema)
+//	if err != nil {
+//	    log.Fatalf("Failed to register schema: %v", err)
+//	}
+//	data, err := avro.MarshalUser(&user)
+//	if err != nil {
+//	    log.Fatalf("Failed to marshal user: %v", err)
+//	}
+//	msg := avro.WithSchemaID(schemaID, data)
+func NewRegistryClient(baseURL string, client *http.Client) *RegistryClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RegistryClient{
+		baseURL: baseURL,
+		client:  client,
+		ids:     make(map[string]int),
+	}
+}
+
+// Register registers schema under subject and returns its schema ID.
+// Registering a schema that the subject already has returns its existing ID.
+func (r *RegistryClient) Register(ctx context.Context, subject, schema string) (int, error) {
+	key := subject + "\x00" + schema
+	r.mu.Lock()
+	id, ok := r.ids[key]
+	r.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	body, err := json.Marshal(map[string]string{"schema": schema})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+"/subjects/"+url.PathEscape(subject)+"/versions", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register schema: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to register schema: unexpected status: %s", resp.Status)
+	}
+
+	var result struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	r.mu.Lock()
+	r.ids[key] = result.ID
+	r.mu.Unlock()
+	return result.ID, nil
+}
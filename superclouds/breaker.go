@@ -0,0 +1,133 @@
+package superclouds
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// defaultOpenTimeout is the OpenTimeout used when CircuitBreakerConfig.OpenTimeout is zero.
+const defaultOpenTimeout = 30 * time.Second
+
+// CircuitBreakerConfig configures the circuit breaker of the transport.
+// After ConsecutiveFailuresThreshold requests in a row fail with a network
+// error or a 5xx status, the breaker opens and requests fail with
+// ErrCircuitOpen for OpenTimeout. It then lets a single trial request
+// through: the breaker closes again if it succeeds and stays open for
+// another OpenTimeout otherwise. A zero threshold disables the breaker.
+type CircuitBreakerConfig struct {
+	ConsecutiveFailuresThreshold uint
+	// OpenTimeout defaults to 30 seconds.
+	OpenTimeout time.Duration
+}
+
+// WithCircuitBreaker sets Config.CircuitBreaker.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithCircuitBreaker(superclouds.CircuitBreakerConfig{
+//	        ConsecutiveFailuresThreshold: 5,
+//	        OpenTimeout:                  time.Minute,
+//	    }),
+//	)
+func WithCircuitBreaker(cb CircuitBreakerConfig) Option {
+	return func(c *Config) {
+		c.CircuitBreaker = cb
+	}
+}
+
+// breakerState is the state of a circuit breaker.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breakerRoundTripper wraps a RoundTripper with a circuit breaker.
+type breakerRoundTripper struct {
+	next        http.RoundTripper
+	threshold   uint
+	openTimeout time.Duration
+	logger      Logger
+
+	mu       sync.Mutex
+	state    breakerState
+	failures uint
+	openedAt time.Time
+}
+
+func newBreakerRoundTripper(next http.RoundTripper, cb CircuitBreakerConfig, logger Logger) *breakerRoundTripper {
+	openTimeout := cb.OpenTimeout
+	if openTimeout <= 0 {
+		openTimeout = defaultOpenTimeout
+	}
+	return &breakerRoundTripper{
+		next:        next,
+		threshold:   cb.ConsecutiveFailuresThreshold,
+		openTimeout: openTimeout,
+		logger:      logger,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (b *breakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !b.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err := b.next.RoundTrip(req)
+	b.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+	return resp, err
+}
+
+// allow reports whether a request may be sent, moving an open breaker whose
+// timeout has passed to half-open for a single trial request.
+func (b *breakerRoundTripper) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// The trial request is still in flight.
+		return false
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a request.
+func (b *breakerRoundTripper) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		if b.state == breakerHalfOpen {
+			b.logger.Info("circuit breaker closed")
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			b.logger.Error("circuit breaker opened", "consecutive_failures", b.failures)
+		}
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
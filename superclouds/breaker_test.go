@@ -0,0 +1,50 @@
+package superclouds_test
+
+import (
+	"errors"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	var requests atomic.Int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv, superclouds.WithCircuitBreaker(superclouds.CircuitBreakerConfig{
+		ConsecutiveFailuresThreshold: 5,
+		OpenTimeout:                  100 * time.Millisecond,
+	}))
+
+	for i := 0; i < 5; i++ {
+		status, err := get(t, cfg, "/users/me")
+		if err != nil || status != http.StatusInternalServerError {
+			t.Fatalf("request %d = %d, %v, want %d", i, status, err, http.StatusInternalServerError)
+		}
+	}
+
+	if _, err := get(t, cfg, "/users/me"); !errors.Is(err, superclouds.ErrCircuitOpen) {
+		t.Fatalf("6th request error = %v, want ErrCircuitOpen", err)
+	}
+	if n := requests.Load(); n != 5 {
+		t.Errorf("server requests = %d, want 5", n)
+	}
+
+	// Once OpenTimeout has passed, a successful trial request closes the breaker.
+	healthy.Store(true)
+	time.Sleep(150 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if status, err := get(t, cfg, "/users/me"); err != nil || status != http.StatusOK {
+			t.Errorf("request after recovery = %d, %v, want %d", status, err, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package superclouds
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// clientCert is the client certificate presented during mutual TLS handshakes.
+// It is read through tls.Config.GetClientCertificate on every handshake, so
+// replacing it takes effect for new connections without rebuilding the client.
+type clientCert struct {
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	// transport is the transport built by setupClient. The SDK wraps it in
+	// round trippers of its own, so Config.Client cannot close its idle connections.
+	transport *http.Transport
+}
+
+func loadClientCert(certPath, keyPath string) (*clientCert, error) {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key pair: %w", err)
+	}
+	return &clientCert{cert: &cert}, nil
+}
+
+func (c *clientCert) get(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+func (c *clientCert) set(cert *tls.Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert = cert
+}
+
+// RotateCert replaces the client certificate used for mutual TLS without restarting the process.
+// The new key pair is loaded first, so a bad path leaves the current certificate in place.
+// Requests in flight are not interrupted; idle connections are closed so that
+// subsequent requests handshake with the new certificate.
+//
+// RotateCert is safe to call while other goroutines are making requests, but
+// it also updates CertPath and KeyPath, which must not be read concurrently.
+//
+// Parameters:
+// - certPath: The path to the new SSL certificate file.
+// - keyPath: The path to the new SSL key file.
+//
+// Example usage:
+//
+//	if err := cfg.RotateCert("/path/to/new-cert.pem", "/path/to/new-key.pem"); err != nil {
+//	    log.Printf("Failed to rotate certificate: %v", err)
+//	}
+func (c *Config) RotateCert(certPath, keyPath string) error {
+	if c.cert == nil {
+		return fmt.Errorf("config does not manage a client certificate")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	c.cert.set(&cert)
+	c.CertPath = certPath
+	c.KeyPath = keyPath
+	if c.cert.transport != nil {
+		c.cert.transport.CloseIdleConnections()
+	}
+	return nil
+}
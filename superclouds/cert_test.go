@@ -0,0 +1,131 @@
+package superclouds_test
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// newMutualTLSServer starts a TLS server that requires a client certificate
+// and answers with the common name of the certificate presented. It returns
+// the server and the path of a PEM file of its certificate.
+func newMutualTLSServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv, writeServerCA(t, srv)
+}
+
+// writeServerCA writes the certificate of srv to a PEM file and returns its path.
+func writeServerCA(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caPath, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA certificate: %v", err)
+	}
+	return caPath
+}
+
+// commonName returns the common name of the client certificate seen by srv.
+func commonName(t *testing.T, cfg *superclouds.Config, srv *httptest.Server) string {
+	t.Helper()
+	resp, err := cfg.Client.Get(srv.URL)
+	if err != nil {
+		t.Errorf("request failed: %v", err)
+		return ""
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Errorf("failed to read response: %v", err)
+	}
+	return string(body)
+}
+
+// newMutualTLSConfig returns a Config that presents the certificate name to srv.
+func newMutualTLSConfig(t *testing.T, srv *httptest.Server, caPath, dir, name string) *superclouds.Config {
+	t.Helper()
+	certPath, keyPath := writeTestCert(t, dir, name)
+	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+		superclouds.WithBaseURL(srv.URL),
+		superclouds.WithToken("test-token"),
+		superclouds.WithCACert(caPath),
+	)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	return cfg
+}
+
+func TestRotateCert(t *testing.T) {
+	srv, caPath := newMutualTLSServer(t)
+	dir := t.TempDir()
+	cfg := newMutualTLSConfig(t, srv, caPath, dir, "original")
+	rotatedCertPath, rotatedKeyPath := writeTestCert(t, dir, "rotated")
+
+	if got := commonName(t, cfg, srv); got != "original" {
+		t.Fatalf("certificate before rotation = %q, want %q", got, "original")
+	}
+	if err := cfg.RotateCert(rotatedCertPath, rotatedKeyPath); err != nil {
+		t.Fatalf("RotateCert: %v", err)
+	}
+	if got := commonName(t, cfg, srv); got != "rotated" {
+		t.Errorf("certificate after rotation = %q, want %q", got, "rotated")
+	}
+	if cfg.CertPath != rotatedCertPath || cfg.KeyPath != rotatedKeyPath {
+		t.Errorf("CertPath, KeyPath = %q, %q, want %q, %q", cfg.CertPath, cfg.KeyPath, rotatedCertPath, rotatedKeyPath)
+	}
+}
+
+func TestRotateCertDuringRequests(t *testing.T) {
+	srv, caPath := newMutualTLSServer(t)
+	dir := t.TempDir()
+	cfg := newMutualTLSConfig(t, srv, caPath, dir, "original")
+	rotatedCertPath, rotatedKeyPath := writeTestCert(t, dir, "rotated")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if got := commonName(t, cfg, srv); got != "original" && got != "rotated" {
+				t.Errorf("certificate during rotation = %q", got)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := cfg.RotateCert(rotatedCertPath, rotatedKeyPath); err != nil {
+			t.Errorf("RotateCert: %v", err)
+		}
+	}()
+	wg.Wait()
+}
+
+func TestRotateCertKeepsCertificateOnError(t *testing.T) {
+	srv, caPath := newMutualTLSServer(t)
+	cfg := newMutualTLSConfig(t, srv, caPath, t.TempDir(), "original")
+	certPath := cfg.CertPath
+
+	if err := cfg.RotateCert(filepath.Join(t.TempDir(), "missing.crt"), cfg.KeyPath); err == nil {
+		t.Fatalf("RotateCert with a missing file returned no error")
+	}
+	if cfg.CertPath != certPath {
+		t.Errorf("CertPath = %q, want %q", cfg.CertPath, certPath)
+	}
+	if got := commonName(t, cfg, srv); got != "original" {
+		t.Errorf("certificate after failed rotation = %q, want %q", got, "original")
+	}
+}
@@ -0,0 +1,76 @@
+// Package clicompat creates a Config from the environment variables of
+// earlier releases as well as the current ones, so that existing scripts
+// keep working while they migrate.
+package clicompat
+
+import (
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"os"
+)
+
+// envVar pairs the current name of an environment variable with its legacy name.
+type envVar struct {
+	name   string
+	legacy string
+}
+
+// The environment variables read by NewConfig, see superclouds.NewConfig.
+var (
+	certVar   = envVar{name: "SUPER_CERT", legacy: "SUPERCLOUD_CERT"}
+	keyVar    = envVar{name: "SUPER_KEY", legacy: "SUPERCLOUD_KEY"}
+	tokenVar  = envVar{name: "SUPER_TOKEN", legacy: "SUPERCLOUD_TOKEN"}
+	urlVar    = envVar{name: "SUPER_URL", legacy: "SUPERCLOUD_URL"}
+	caCertVar = envVar{name: "SUPER_CA_CERT", legacy: "SUPERCLOUD_CA_CERT"}
+)
+
+// NewConfig creates a new Config instance like superclouds.NewConfig, but
+// also accepts the legacy SUPERCLOUD_ names of its environment variables:
+// SUPERCLOUD_CERT, SUPERCLOUD_KEY, SUPERCLOUD_TOKEN, SUPERCLOUD_URL and
+// SUPERCLOUD_CA_CERT. When both names are set, the current SUPER_ name wins.
+// A deprecation warning is written to os.Stderr for each legacy name used.
+//
+// Example usage:
+//
+//	cfg, err := clicompat.NewConfig()
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+func NewConfig() (*superclouds.Config, error) {
+	certPath := lookup(certVar)
+	if certPath == "" {
+		return nil, fmt.Errorf("missing %s environment variable", certVar.name)
+	}
+
+	keyPath := lookup(keyVar)
+	if keyPath == "" {
+		return nil, fmt.Errorf("missing %s environment variable", keyVar.name)
+	}
+
+	superToken := lookup(tokenVar)
+	if superToken == "" {
+		return nil, fmt.Errorf("missing %s environment variable", tokenVar.name)
+	}
+
+	opts := []superclouds.Option{superclouds.WithToken(superToken)}
+	if superURL := lookup(urlVar); superURL != "" {
+		opts = append(opts, superclouds.WithBaseURL(superURL))
+	}
+	if caCertPath := lookup(caCertVar); caCertPath != "" {
+		opts = append(opts, superclouds.WithCACert(caCertPath))
+	}
+
+	return superclouds.NewConfigWithOptions(certPath, keyPath, opts...)
+}
+
+// lookup returns the value of v, falling back to its legacy name with a deprecation warning.
+func lookup(v envVar) string {
+	if value := os.Getenv(v.name); value != "" {
+		return value
+	}
+	value := os.Getenv(v.legacy)
+	if value != "" {
+		fmt.Fprintf(os.Stderr, "superclouds: the %s environment variable is deprecated, use %s instead\n", v.legacy, v.name)
+	}
+	return value
+}
@@ -0,0 +1,394 @@
+// Package v2 lets callers adopt the types of version 2 of the Superclouds API
+// ahead of its release. V2UsersClient takes and returns v2 types, and
+// translates them to and from the v1 types of a users.UsersClient, so that
+// call sites can be migrated one at a time. Fields that version 1 cannot
+// represent are dropped, and the dropped fields are logged.
+package v2
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"strings"
+	"time"
+)
+
+// User represents a user in the shape of API v2.
+// Roles holds the name of the user's v1 role: "ADMIN", "MODIFY" or "VIEW",
+// the most privileged one whose permissions the user has. It is empty when
+// the user has none of them.
+type User struct {
+	ID          string
+	Email       string
+	GivenName   string
+	FamilyName  string
+	Roles       []string
+	Status      string
+	PhoneNumber string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	LastLoginAt *time.Time
+}
+
+// ListUsersInput defines the input parameters for the ListUsers method.
+// OrderBy is a field name, optionally followed by " desc", such as "email desc".
+type ListUsersInput struct {
+	PageSize  int
+	PageToken string
+	Query     string
+	Role      string
+	OrderBy   string
+}
+
+// ListUsersOutput defines the output structure for the ListUsers method.
+// NextPageToken is empty on the last page.
+type ListUsersOutput struct {
+	Users         []User
+	NextPageToken string
+}
+
+// CreateUserInput defines the input parameters for the CreateUser method.
+// Version 1 assigns a single role and has no phone number on creation, so
+// only the first of Roles is kept and PhoneNumber is dropped.
+type CreateUserInput struct {
+	Email       string
+	GivenName   string
+	FamilyName  string
+	Roles       []string
+	PhoneNumber string
+}
+
+// UpdateUserInput defines the input parameters for the UpdateUser method.
+type UpdateUserInput struct {
+	GivenName   string
+	FamilyName  string
+	PhoneNumber string
+}
+
+// DeleteUserInput defines the input parameters for the DeleteUser method.
+type DeleteUserInput struct {
+	Email string
+}
+
+// UpdateUserRolesInput defines the input parameters for the UpdateUserRoles method.
+// Version 1 assigns a single role, so only the first of Roles is kept.
+type UpdateUserRolesInput struct {
+	Email string
+	Roles []string
+}
+
+// Option configures a V2UsersClient created by NewUsersClient.
+type Option func(*V2UsersClient)
+
+// WithLogger sets the Logger that receives the fields dropped in translation.
+// By default they are not logged.
+func WithLogger(logger superclouds.Logger) Option {
+	return func(c *V2UsersClient) {
+		c.logger = logger
+	}
+}
+
+// V2UsersClient provides the users methods of the Superclouds API with v2 types, on top of a v1 client.
+type V2UsersClient struct {
+	v1     *users.UsersClient
+	logger superclouds.Logger
+}
+
+// NewUsersClient creates a new V2UsersClient that sends its requests with v1client.
+//
+// Parameters:
+// - v1client: The v1 client the requests are sent with.
+// - opts: The options to apply, such as WithLogger.
+//
+// Example usage:
+//
+//	usersClient := v2.NewUsersClient(users.NewUsersClient(cfg))
+func NewUsersClient(v1client *users.UsersClient, opts ...Option) *V2UsersClient {
+	c := &V2UsersClient{v1: v1client, logger: superclouds.NopLogger{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ListUsers retrieves a page of users.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - ListUsersOutput: The users and the token of the next page.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	output, err := usersClient.ListUsers(context.TODO(), &v2.ListUsersInput{
+//	    PageSize: 10,
+//	    OrderBy:  "email desc",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to list users: %v", err)
+//	}
+func (c *V2UsersClient) ListUsers(ctx context.Context, input *ListUsersInput) (*ListUsersOutput, error) {
+	if input == nil {
+		input = &ListUsersInput{}
+	}
+	role, err := toV1Role(input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	v1Input := &users.ListUsersInput{
+		Size:       input.PageSize,
+		PageToken:  input.PageToken,
+		SearchTerm: input.Query,
+		Role:       role,
+	}
+	if input.OrderBy != "" {
+		field, order, _ := strings.Cut(input.OrderBy, " ")
+		v1Input.SortBy = field
+		v1Input.SortOrder = strings.ToLower(strings.TrimSpace(order))
+	}
+
+	v1Output, err := c.v1.ListUsers(ctx, v1Input)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListUsersOutput{
+		Users:         make([]User, len(v1Output.Users)),
+		NextPageToken: v1Output.NextPageToken,
+	}
+	for i, u := range v1Output.Users {
+		output.Users[i] = fromV1User(u)
+	}
+	return output, nil
+}
+
+// CreateUser creates a new user.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - User: The created user.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	newUser, err := usersClient.CreateUser(context.TODO(), &v2.CreateUserInput{
+//	    Email:      "new.user@example.com",
+//	    GivenName:  "John",
+//	    FamilyName: "Doe",
+//	    Roles:      []string{"MODIFY"},
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to create user: %v", err)
+//	}
+func (c *V2UsersClient) CreateUser(ctx context.Context, input *CreateUserInput) (*User, error) {
+	role, err := toV1Role(firstRole(input.Roles))
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	if len(input.Roles) > 1 {
+		dropped = append(dropped, "Roles[1:]")
+	}
+	if input.PhoneNumber != "" {
+		dropped = append(dropped, "PhoneNumber")
+	}
+	c.logDropped("create_user", dropped)
+
+	v1Output, err := c.v1.CreateUser(ctx, &users.CreateUserInput{
+		Email:     input.Email,
+		FirstName: input.GivenName,
+		LastName:  input.FamilyName,
+		Role:      role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user := fromV1User(v1Output.User)
+	return &user, nil
+}
+
+// GetUser retrieves the authenticated user.
+//
+// Parameters:
+// - ctx: The context for the request.
+//
+// Returns:
+// - User: The authenticated user.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	user, err := usersClient.GetUser(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get user: %v", err)
+//	}
+func (c *V2UsersClient) GetUser(ctx context.Context) (*User, error) {
+	v1Output, err := c.v1.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user := fromV1User(v1Output.User)
+	return &user, nil
+}
+
+// UpdateUser updates the details of the authenticated user.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - User: The updated user.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	updatedUser, err := usersClient.UpdateUser(context.TODO(), &v2.UpdateUserInput{
+//	    PhoneNumber: "999XXXX999",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update user: %v", err)
+//	}
+func (c *V2UsersClient) UpdateUser(ctx context.Context, input *UpdateUserInput) (*User, error) {
+	v1Output, err := c.v1.UpdateUser(ctx, &users.UpdateUserInput{
+		FirstName: input.GivenName,
+		LastName:  input.FamilyName,
+		Contact:   input.PhoneNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user := fromV1User(v1Output.User)
+	return &user, nil
+}
+
+// DeleteUser removes a user from the organization.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := usersClient.DeleteUser(context.TODO(), &v2.DeleteUserInput{
+//	    Email: "delete.user@example.com",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to delete user: %v", err)
+//	}
+func (c *V2UsersClient) DeleteUser(ctx context.Context, input *DeleteUserInput) error {
+	return c.v1.DeleteUser(ctx, &users.DeleteUserInput{Email: input.Email})
+}
+
+// UpdateUserRoles updates the roles of a user within the organization.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := usersClient.UpdateUserRoles(context.TODO(), &v2.UpdateUserRolesInput{
+//	    Email: "user@example.com",
+//	    Roles: []string{"MODIFY"},
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update user roles: %v", err)
+//	}
+func (c *V2UsersClient) UpdateUserRoles(ctx context.Context, input *UpdateUserRolesInput) error {
+	if len(input.Roles) == 0 {
+		return fmt.Errorf("missing role")
+	}
+	role, err := toV1Role(input.Roles[0])
+	if err != nil {
+		return err
+	}
+	if len(input.Roles) > 1 {
+		c.logDropped("update_user_roles", []string{"Roles[1:]"})
+	}
+
+	return c.v1.UpdateUserRole(ctx, &users.UpdateUserRoleInput{
+		Email: input.Email,
+		Role:  string(role),
+	})
+}
+
+// logDropped logs the fields of an operation's input that version 1 cannot represent.
+func (c *V2UsersClient) logDropped(operation string, fields []string) {
+	if len(fields) > 0 {
+		c.logger.Info("fields dropped in translation to API v1", "operation", operation, "fields", fields)
+	}
+}
+
+// v1Roles maps the names of the v1 roles to the permissions they grant,
+// from the most privileged to the least. Role names are translated both
+// ways through it, so that a user's roles can be sent back as they came.
+var v1Roles = []struct {
+	name        users.RoleName
+	permissions users.Role
+}{
+	{users.RoleAdmin, users.READ | users.MODIFY | users.MANAGE},
+	{users.RoleModify, users.READ | users.MODIFY},
+	{users.RoleView, users.READ},
+}
+
+// toV1Role returns the v1 role named name, or "" if name is empty.
+func toV1Role(name string) (users.RoleName, error) {
+	if name == "" {
+		return "", nil
+	}
+	for _, r := range v1Roles {
+		if string(r.name) == name {
+			return r.name, nil
+		}
+	}
+	return "", fmt.Errorf("invalid role %q: must be one of %q, %q or %q", name, users.RoleAdmin, users.RoleModify, users.RoleView)
+}
+
+// fromV1User translates a v1 user. Every v1 field has a v2 counterpart; the
+// permissions of the role are named after the v1 role that grants them.
+func fromV1User(u users.User) User {
+	user := User{
+		ID:          u.Id,
+		Email:       u.Email,
+		GivenName:   u.FirstName,
+		FamilyName:  u.LastName,
+		Status:      u.Status,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+		LastLoginAt: u.LastLoginAt,
+	}
+	for _, r := range v1Roles {
+		if u.Role&r.permissions == r.permissions {
+			user.Roles = []string{string(r.name)}
+			break
+		}
+	}
+	return user
+}
+
+// firstRole returns the first of roles, or "" if there are none.
+func firstRole(roles []string) string {
+	if len(roles) == 0 {
+		return ""
+	}
+	return roles[0]
+}
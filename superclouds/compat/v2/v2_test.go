@@ -0,0 +1,92 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/compat/v2"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// permissions are the permissions the API grants to each v1 role.
+var permissions = map[string]users.Role{
+	"ADMIN":  users.READ | users.MODIFY | users.MANAGE,
+	"MODIFY": users.READ | users.MODIFY,
+	"VIEW":   users.READ,
+}
+
+// newTestClient returns a V2UsersClient whose CreateUser requests are answered
+// with a user that has the permissions of the role sent.
+func newTestClient(t *testing.T) *v2.V2UsersClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input users.CreateUserInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if input.Role != "" && !input.Role.Valid() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":1,"data":{"id":"user-1","email":%q,"role":%d}}`, input.Email, permissions[string(input.Role)])
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg, err := superclouds.NewConfigWithOptions("", "",
+		superclouds.WithBaseURL(srv.URL),
+		superclouds.WithToken("test-token"),
+		superclouds.WithHTTPClient(srv.Client()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	return v2.NewUsersClient(users.NewUsersClient(cfg))
+}
+
+func TestRolesRoundTrip(t *testing.T) {
+	client := newTestClient(t)
+
+	for _, role := range []string{"ADMIN", "MODIFY", "VIEW"} {
+		created, err := client.CreateUser(context.Background(), &v2.CreateUserInput{
+			Email: "new.user@example.com",
+			Roles: []string{role},
+		})
+		if err != nil {
+			t.Fatalf("CreateUser with role %s: %v", role, err)
+		}
+		if len(created.Roles) != 1 || created.Roles[0] != role {
+			t.Errorf("roles of a user created with %s = %q, want [%s]", role, created.Roles, role)
+		}
+
+		// The roles of a user are accepted back as they came.
+		if _, err := client.CreateUser(context.Background(), &v2.CreateUserInput{
+			Email: "copy.user@example.com",
+			Roles: created.Roles,
+		}); err != nil {
+			t.Errorf("CreateUser with the roles %q of a created user: %v", created.Roles, err)
+		}
+	}
+}
+
+func TestInvalidRole(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.CreateUser(context.Background(), &v2.CreateUserInput{
+		Email: "new.user@example.com",
+		Roles: []string{"SUPER"},
+	})
+	if err == nil {
+		t.Errorf("CreateUser with role SUPER returned no error")
+	}
+	if err := client.UpdateUserRoles(context.Background(), &v2.UpdateUserRolesInput{
+		Email: "user@example.com",
+		Roles: []string{"READ"},
+	}); err == nil {
+		t.Errorf("UpdateUserRoles with role READ returned no error")
+	}
+}
@@ -0,0 +1,98 @@
+// Package concurrent provides the concurrency primitives used by the SDK's bulk operations.
+package concurrent
+
+import (
+	"context"
+	"fmt"
+)
+
+// Semaphore is a weighted semaphore that bounds the concurrency of SDK fan-out.
+// It is the canonical building block for bulk operations: acquire a weight
+// before starting a request and release it once the request has finished.
+//
+// Each unit of weight is a slot in a buffered channel. Acquirers take a lock,
+// itself a one-slot channel so that waiting for it honours context
+// cancellation, before claiming their slots, so that two weighted
+// acquisitions never each hold part of the capacity and deadlock.
+//
+// Example usage:
+//
+//	sem := concurrent.NewSemaphore(8)
+//	for _, input := range inputs {
+//	    if err := sem.Acquire(ctx, 1); err != nil {
+//	        return err
+//	    }
+//	    go func(input *users.CreateUserInput) {
+//	        defer sem.Release(1)
+//	        usersClient.CreateUser(ctx, input)
+//	    }(input)
+//	}
+type Semaphore struct {
+	size   int64
+	lock   chan struct{}
+	tokens chan struct{}
+}
+
+// NewSemaphore creates a Semaphore with the given total weight.
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{
+		size:   size,
+		lock:   make(chan struct{}, 1),
+		tokens: make(chan struct{}, size),
+	}
+}
+
+// Acquire blocks until a weight of n is available or ctx is done.
+// On failure it returns ctx.Err() and leaves the semaphore unchanged.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	if n > s.size {
+		return fmt.Errorf("semaphore: weight %d exceeds size %d", n, s.size)
+	}
+
+	select {
+	case s.lock <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.lock }()
+
+	for i := int64(0); i < n; i++ {
+		select {
+		case s.tokens <- struct{}{}:
+		case <-ctx.Done():
+			s.Release(i)
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// TryAcquire acquires a weight of n without blocking and reports whether it succeeded.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	select {
+	case s.lock <- struct{}{}:
+	default:
+		return false
+	}
+	defer func() { <-s.lock }()
+
+	if int64(len(s.tokens))+n > s.size {
+		return false
+	}
+	for i := int64(0); i < n; i++ {
+		s.tokens <- struct{}{}
+	}
+	return true
+}
+
+// Release releases a weight of n previously acquired.
+// Releasing more than is held panics.
+func (s *Semaphore) Release(n int64) {
+	for i := int64(0); i < n; i++ {
+		select {
+		case <-s.tokens:
+		default:
+			panic("semaphore: released more than held")
+		}
+	}
+}
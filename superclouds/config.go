@@ -3,18 +3,91 @@ package superclouds
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http2"
+	"golang.org/x/time/rate"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"time"
 )
 
 // Config contains the configuration settings for connecting to the Superclouds API.
+// CACertPath is the path to a PEM file of CA certificates used to verify the
+// server; when it is empty, the system certificate pool is used.
 type Config struct {
 	SuperURL   string
 	CertPath   string
 	KeyPath    string
+	CACertPath string
 	SuperToken string
 	Client     *http.Client
+	// APIVersion is the version of the API that requests are sent to, see URL.
+	// It defaults to DefaultAPIVersion.
+	APIVersion string
+	// FieldMask is the default list of fields requested by list methods, see WithFieldMask.
+	FieldMask []string
+	// MaxDebugBodyBytes is the number of body bytes written by WithDebugWriter; it defaults to 4096.
+	// It is read when the client is built, so set it from an Option.
+	MaxDebugBodyBytes int
+	// MaxRequestBodyBytes is the largest request body the SDK sends; requests with
+	// larger bodies fail with ErrRequestBodyTooLarge. Zero means no limit.
+	MaxRequestBodyBytes int64
+	// MaxResponseBodyBytes is the largest response body the SDK reads; larger bodies
+	// fail with ErrResponseBodyTooLarge. It defaults to DefaultMaxResponseBodyBytes; zero means no limit.
+	MaxResponseBodyBytes int64
+	// RequestTimeout bounds each request whose context has no deadline; zero disables it.
+	// It defaults to DefaultRequestTimeout and is read when the client is built, so set it with WithRequestTimeout.
+	RequestTimeout time.Duration
+	// CircuitBreaker makes requests fail fast while the API keeps failing; it is disabled by default.
+	// It is read when the client is built, so set it with WithCircuitBreaker.
+	CircuitBreaker CircuitBreakerConfig
+
+	// cert holds the client certificate presented during the TLS handshake, so that it can be rotated.
+	cert *clientCert
+	// insecureSkipVerify disables server certificate verification, see WithInsecureSkipVerify.
+	insecureSkipVerify bool
+	// tokenProvider supplies the bearer token in place of SuperToken, see WithTokenProvider.
+	tokenProvider TokenProvider
+	// tracer records a span per request, see WithTracer.
+	tracer trace.Tracer
+	// metrics records Prometheus metrics per request, see WithMetrics.
+	metrics *metrics
+	// proxyURL is the proxy of the transport built by setupClient, see WithProxy.
+	proxyURL *url.URL
+	// http2 enables HTTP/2 on the transport built by setupClient, see WithHTTP2.
+	http2 bool
+	// transportOptions tunes the transport built by setupClient, see WithTransportOptions.
+	transportOptions TransportOptions
+	// logger receives the SDK's log messages, see WithLogger.
+	logger Logger
+	// rateLimiter paces requests, see WithRateLimit.
+	rateLimiter *rate.Limiter
+	// retryConfig controls which failed requests are retried, see WithRetryConfig.
+	retryConfig RetryConfig
+	// requestIDGenerator generates the X-Request-ID header of each request, see WithRequestIDGenerator.
+	requestIDGenerator func() string
+	// staticHeaders are added to every request, see WithStaticHeaders.
+	staticHeaders http.Header
+	// debugWriter receives a dump of every request and response, see WithDebugWriter.
+	debugWriter io.Writer
+	// debugBodyFilter rewrites the bodies written by the debug transport, see WithDebugBodyFilter.
+	debugBodyFilter func([]byte) []byte
+	// debugRedactIdentity hides query values and identity headers from the debug transport, see WithDebugIdentityRedaction.
+	debugRedactIdentity bool
+	// responseDecoder decodes response bodies in place of encoding/json, see WithResponseDecoder.
+	responseDecoder func(r io.Reader, v interface{}) error
+	// errorTranslator rewrites the errors of API error responses, see WithErrorTranslator.
+	errorTranslator func(error) error
+	// middlewares wrap the transport, see WithMiddleware.
+	middlewares []func(http.RoundTripper) http.RoundTripper
+	// optionErr is the first error of an option, returned by NewConfigWithOptions.
+	optionErr error
+	// values holds the settings of packages built on Config, see SetValue.
+	values map[interface{}]interface{}
 }
 
 // NewConfig creates a new Config instance using environment variables for cert and key paths, and token.
@@ -22,6 +95,7 @@ type Config struct {
 // - SUPER_CERT: The path to the SSL certificate file.
 // - SUPER_KEY: The path to the SSL key file.
 // - SUPER_TOKEN: The bearer token for API authorization.
+// - SUPER_CA_CERT: Optional path to the CA certificate file used to verify the server.
 //
 // Example usage:
 //
@@ -30,78 +104,301 @@ type Config struct {
 //	    log.Fatalf("Failed to create config: %v", err)
 //	}
 func NewConfig() (*Config, error) {
-	certPath := os.Getenv("SUPER_CERT")
+	return NewConfigFromEnvPrefix("SUPER")
+}
+
+// NewConfigFromEnvPrefix creates a new Config instance using environment variables that share the given prefix.
+// This allows a single process to hold configs for several Superclouds endpoints, such as staging and production.
+// The environment variables read are:
+// - {PREFIX}_CERT: The path to the SSL certificate file.
+// - {PREFIX}_KEY: The path to the SSL key file.
+// - {PREFIX}_TOKEN: The bearer token for API authorization.
+// - {PREFIX}_URL: Optional base URL of the Superclouds API; the default API URL is used when unset.
+// - {PREFIX}_CA_CERT: Optional path to the CA certificate file used to verify the server.
+//
+// Example usage:
+//
+//	prodCfg, err := superclouds.NewConfigFromEnvPrefix("PROD")
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+//	stagingCfg, err := superclouds.NewConfigFromEnvPrefix("STAGING")
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+func NewConfigFromEnvPrefix(prefix string) (*Config, error) {
+	certPath := os.Getenv(prefix + "_CERT")
 	if certPath == "" {
-		return nil, fmt.Errorf("missing SUPER_CERT environment variable")
+		return nil, fmt.Errorf("missing %s_CERT environment variable", prefix)
 	}
 
-	keyPath := os.Getenv("SUPER_KEY")
+	keyPath := os.Getenv(prefix + "_KEY")
 	if keyPath == "" {
-		return nil, fmt.Errorf("missing SUPER_KEY environment variable")
+		return nil, fmt.Errorf("missing %s_KEY environment variable", prefix)
 	}
 
-	superToken := os.Getenv("SUPER_TOKEN")
+	superToken := os.Getenv(prefix + "_TOKEN")
 	if superToken == "" {
-		return nil, fmt.Errorf("missing SUPER_TOKEN environment variable")
+		return nil, fmt.Errorf("missing %s_TOKEN environment variable", prefix)
+	}
+
+	opts := []Option{WithToken(superToken)}
+	if superURL := os.Getenv(prefix + "_URL"); superURL != "" {
+		opts = append(opts, WithBaseURL(superURL))
+	}
+	if caCertPath := os.Getenv(prefix + "_CA_CERT"); caCertPath != "" {
+		opts = append(opts, WithCACert(caCertPath))
 	}
 
-	client, err := setupClient(certPath, keyPath)
+	return NewConfigWithOptions(certPath, keyPath, opts...)
+}
+
+// ConfigFile is the JSON document read by NewConfigFromFile.
+// BaseURL is optional; the default API URL is used when it is empty.
+// CACertPath is optional; the system certificate pool is used when it is empty.
+type ConfigFile struct {
+	CertPath   string `json:"cert_path"`
+	KeyPath    string `json:"key_path"`
+	Token      string `json:"token"`
+	BaseURL    string `json:"base_url"`
+	CACertPath string `json:"ca_cert_path"`
+}
+
+// NewConfigFromFile creates a new Config instance from a JSON file.
+// The file must contain the keys cert_path, key_path and token, and may contain base_url and ca_cert_path.
+//
+// Example file:
+//
+//	{
+//	    "cert_path": "/path/to/cert.pem",
+//	    "key_path": "/path/to/key.pem",
+//	    "token": "your-api-token"
+//	}
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigFromFile("/etc/superclouds/config.json")
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+func NewConfigFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var file ConfigFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
 	}
 
-	return &Config{
-		SuperURL:   apiBaseURL,
-		CertPath:   certPath,
-		KeyPath:    keyPath,
-		SuperToken: superToken,
-		Client:     client,
-	}, nil
+	if file.CertPath == "" {
+		return nil, fmt.Errorf("missing cert_path in config file %s", path)
+	}
+	if file.KeyPath == "" {
+		return nil, fmt.Errorf("missing key_path in config file %s", path)
+	}
+	if file.Token == "" {
+		return nil, fmt.Errorf("missing token in config file %s", path)
+	}
+
+	opts := []Option{WithToken(file.Token)}
+	if file.BaseURL != "" {
+		opts = append(opts, WithBaseURL(file.BaseURL))
+	}
+	if file.CACertPath != "" {
+		opts = append(opts, WithCACert(file.CACertPath))
+	}
+
+	return NewConfigWithOptions(file.CertPath, file.KeyPath, opts...)
 }
 
-// NewConfigWithParams creates a new Config instance using provided parameters for cert and key paths, and token.
+// NewConfigWithParams creates a new Config instance using provided parameters for cert and key paths, token, and CA certificate path.
 //
 // Parameters:
 // - certPath: The path to the SSL certificate file.
 // - keyPath: The path to the SSL key file.
 // - token: The bearer token for API authorization.
+// - caCertPath: The path to the CA certificate file used to verify the server, or "" to use the system certificate pool.
 //
 // Example usage:
 //
-//	cfg, err := superclouds.NewConfigWithParams(certPath, keyPath, superToken)
+//	cfg, err := superclouds.NewConfigWithParams(certPath, keyPath, superToken, "")
 //	if err != nil {
 //	    log.Fatalf("Failed to create config: %v", err)
 //	}
-func NewConfigWithParams(certPath, keyPath, token string) (*Config, error) {
-	client, err := setupClient(certPath, keyPath)
-	if err != nil {
-		return nil, err
+func NewConfigWithParams(certPath, keyPath, token, caCertPath string) (*Config, error) {
+	return NewConfigWithOptions(certPath, keyPath, WithToken(token), WithCACert(caCertPath))
+}
+
+// NewConfigWithToken creates a new Config instance that authenticates with the bearer token only, without mTLS.
+// The server certificate is verified against the system certificate pool, and CertPath and KeyPath are left empty.
+//
+// Parameters:
+// - token: The bearer token for API authorization.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithToken(superToken)
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+func NewConfigWithToken(token string) (*Config, error) {
+	return NewConfigWithOptions("", "", WithToken(token))
+}
+
+// NewConfigWithTokenAndCA creates a new Config instance that authenticates with the bearer token only, without mTLS,
+// and verifies the server against the given CA certificate instead of the system certificate pool.
+//
+// Parameters:
+// - token: The bearer token for API authorization.
+// - caCertPath: The path to the CA certificate file used to verify the server.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithTokenAndCA(superToken, "/path/to/ca.pem")
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+func NewConfigWithTokenAndCA(token, caCertPath string) (*Config, error) {
+	if caCertPath == "" {
+		return nil, fmt.Errorf("missing CA certificate path")
+	}
+	return NewConfigWithOptions("", "", WithToken(token), WithCACert(caCertPath))
+}
+
+// Validate checks that the configuration is usable before any request is made.
+// It verifies that SuperURL is an absolute URL, that SuperToken or a TokenProvider is set and
+// that the certificate and key files exist on disk. CertPath and KeyPath may
+// both be empty for bearer-only configs, but not just one of them.
+//
+// Example usage:
+//
+//	if err := cfg.Validate(); err != nil {
+//	    log.Fatalf("Invalid config: %v", err)
+//	}
+func (c *Config) Validate() error {
+	if _, err := url.ParseRequestURI(c.SuperURL); err != nil {
+		return fmt.Errorf("invalid SuperURL %q: %w", c.SuperURL, err)
+	}
+
+	if c.SuperToken == "" && c.tokenProvider == nil {
+		return fmt.Errorf("missing SuperToken")
+	}
+
+	if (c.CertPath == "") != (c.KeyPath == "") {
+		return fmt.Errorf("CertPath and KeyPath must be set together")
+	}
+
+	if c.CertPath != "" {
+		if _, err := os.Stat(c.CertPath); err != nil {
+			return fmt.Errorf("invalid CertPath: %w", err)
+		}
+
+		if _, err := os.Stat(c.KeyPath); err != nil {
+			return fmt.Errorf("invalid KeyPath: %w", err)
+		}
+	}
+
+	if c.CACertPath != "" {
+		if _, err := os.Stat(c.CACertPath); err != nil {
+			return fmt.Errorf("invalid CACertPath: %w", err)
+		}
 	}
 
-	return &Config{
-		SuperURL:   apiBaseURL,
-		CertPath:   certPath,
-		KeyPath:    keyPath,
-		SuperToken: token,
-		Client:     client,
-	}, nil
+	return nil
 }
 
-func setupClient(certPath, keyPath string) (*http.Client, error) {
-	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load key pair: %v", err)
+// Clone returns a shallow copy of the Config with its own http.Client.
+// The new client shares the underlying Transport, so connections are reused,
+// while its fields and those of the copy can be changed without affecting c.
+// This lets multi-tenant callers derive a per-tenant Config from a shared one.
+//
+// Example usage:
+//
+//	tenantCfg := cfg.Clone()
+//	tenantCfg.SuperToken = tenantToken
+//	tenantUsers := users.NewUsersClient(tenantCfg)
+func (c *Config) Clone() *Config {
+	clone := *c
+	if c.Client != nil {
+		client := *c.Client
+		clone.Client = &client
+	}
+	if c.values != nil {
+		clone.values = make(map[interface{}]interface{}, len(c.values))
+		for key, value := range c.values {
+			clone.values[key] = value
+		}
+	}
+	return &clone
+}
+
+// SetValue stores value under key. It lets packages built on Config, such as
+// users, offer options of their own without Config depending on them. Like
+// context keys, key should be of an unexported type of the package that sets it.
+func (c *Config) SetValue(key, value interface{}) {
+	if c.values == nil {
+		c.values = make(map[interface{}]interface{})
+	}
+	c.values[key] = value
+}
+
+// Value returns the value stored under key with SetValue, or nil.
+func (c *Config) Value(key interface{}) interface{} {
+	return c.values[key]
+}
+
+func setupClient(cfg *Config) (*http.Client, *clientCert, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.insecureSkipVerify,
+	}
+
+	// Without a certificate and key, only the server side of the connection is authenticated.
+	var cert *clientCert
+	if cfg.CertPath != "" || cfg.KeyPath != "" {
+		var err error
+		cert, err = loadClientCert(cfg.CertPath, cfg.KeyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		tlsConfig.GetClientCertificate = cert.get
+	}
+
+	// Leaving RootCAs nil makes crypto/tls verify the server against the system roots.
+	if cfg.CACertPath != "" {
+		caCert, err := os.ReadFile(cfg.CACertPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, nil, fmt.Errorf("no certificates found in %s", cfg.CACertPath)
+		}
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+	cfg.transportOptions.apply(transport)
+	if cfg.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(cfg.proxyURL)
+	}
+
+	// A transport with a custom TLS config only speaks HTTP/1.1 unless HTTP/2 is enabled explicitly.
+	if cfg.http2 {
+		transport.ForceAttemptHTTP2 = true
+		if err := http2.ConfigureTransport(transport); err != nil {
+			return nil, nil, fmt.Errorf("failed to configure HTTP/2: %w", err)
+		}
+	}
+
+	if cert != nil {
+		cert.transport = transport
 	}
 
-	caCertPool := x509.NewCertPool()
 	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-				Certificates:       []tls.Certificate{cert},
-				RootCAs:            caCertPool,
-			},
-		},
-	}
-	return client, nil
+		Transport: transport,
+	}
+	return client, cert, nil
 }
@@ -0,0 +1,150 @@
+// Package remote loads the SDK configuration from a control plane endpoint
+// and keeps it up to date by polling.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Loader polls an endpoint that serves a superclouds.ConfigFile JSON document
+// and builds a new superclouds.Config each time the document changes.
+// Fields missing from the document keep the values of the Loader's options.
+type Loader struct {
+	url      string
+	interval time.Duration
+	onChange func(*superclouds.Config)
+	opts     []superclouds.Option
+	client   *http.Client
+
+	// mu serializes loads, so that configs are applied in the order they are fetched.
+	mu      sync.Mutex
+	last    *superclouds.ConfigFile
+	current atomic.Pointer[superclouds.Config]
+}
+
+// NewLoader creates a Loader that polls url every interval and calls onChange
+// with each new Config. opts are applied to every Config before the fields
+// of the document, so they can supply defaults such as a logger or a token.
+//
+// Example usage:
+//
+//	loader := remote.NewLoader("https://control-plane.internal/superclouds.json", time.Minute,
+//	    func(cfg *superclouds.Config) {
+//	        usersClient.Store(users.NewUsersClient(cfg))
+//	    },
+//	    superclouds.WithToken(superToken),
+//	)
+//	if err := loader.Load(ctx); err != nil {
+//	    log.Fatalf("Failed to load config: %v", err)
+//	}
+//	go loader.Run(ctx)
+func NewLoader(url string, interval time.Duration, onChange func(*superclouds.Config), opts ...superclouds.Option) *Loader {
+	return &Loader{
+		url:      url,
+		interval: interval,
+		onChange: onChange,
+		opts:     opts,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Config returns the current Config, or nil before the first successful load.
+func (l *Loader) Config() *superclouds.Config {
+	return l.current.Load()
+}
+
+// Load fetches the document once. If it changed since the last load, Load
+// builds a new Config, makes it the current one and calls onChange with it.
+// On error, the current Config is kept.
+func (l *Loader) Load(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := l.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if l.last != nil && *l.last == *file {
+		return nil
+	}
+
+	opts := append([]superclouds.Option{}, l.opts...)
+	if file.Token != "" {
+		opts = append(opts, superclouds.WithToken(file.Token))
+	}
+	if file.BaseURL != "" {
+		opts = append(opts, superclouds.WithBaseURL(file.BaseURL))
+	}
+	if file.CACertPath != "" {
+		opts = append(opts, superclouds.WithCACert(file.CACertPath))
+	}
+	cfg, err := superclouds.NewConfigWithOptions(file.CertPath, file.KeyPath, opts...)
+	if err != nil {
+		return fmt.Errorf("invalid remote config: %w", err)
+	}
+
+	l.last = file
+	l.current.Store(cfg)
+	if l.onChange != nil {
+		l.onChange(cfg)
+	}
+	return nil
+}
+
+// Run calls Load right away and then every interval, until ctx is done.
+// Failed loads are logged with the Logger of the current Config.
+func (l *Loader) Run(ctx context.Context) error {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := l.Load(ctx); err != nil && ctx.Err() == nil {
+			l.logger().Error("failed to load remote config", "url", l.url, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// logger returns the Logger of the current Config, or a NopLogger.
+func (l *Loader) logger() superclouds.Logger {
+	if cfg := l.Config(); cfg != nil {
+		return cfg.Logger()
+	}
+	return superclouds.NopLogger{}
+}
+
+// fetch retrieves and decodes the document.
+func (l *Loader) fetch(ctx context.Context) (*superclouds.ConfigFile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	var file superclouds.ConfigFile
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	return &file, nil
+}
@@ -0,0 +1,96 @@
+// Package template creates a Config from a config file written as a Go
+// text/template, so that deployment tooling can keep one template per
+// environment and fill in the values at startup.
+package template
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"os"
+	"path/filepath"
+	stdtemplate "text/template"
+)
+
+// funcs are the functions available to templates, in addition to the
+// built-in functions of text/template:
+//   - env NAME: the value of the environment variable NAME, or "" if it is unset.
+//   - b64dec S: S decoded from standard base64; invalid input fails the rendering.
+var funcs = stdtemplate.FuncMap{
+	"env": os.Getenv,
+	"b64dec": func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode base64: %w", err)
+		}
+		return string(b), nil
+	},
+}
+
+// ParseFile creates a new Config instance from a template of the JSON file
+// read by superclouds.NewConfigFromFile. The file is rendered with data as
+// the template context, and the rendered document must contain the keys
+// cert_path, key_path and token. Templates can read environment variables
+// with env and decode base64 values with b64dec. Missing keys of a map in
+// data fail the rendering.
+//
+// Parameters:
+// - path: The path to the template file.
+// - data: The context the template is rendered with.
+//
+// Returns:
+// - Config: The Config built from the rendered document.
+// - error: Any error encountered reading, rendering or parsing the file.
+//
+// Example file:
+//
+//	{
+//	    "cert_path": "/etc/superclouds/{{ .Env }}/cert.pem",
+//	    "key_path": "/etc/superclouds/{{ .Env }}/key.pem",
+//	    "token": "{{ env "SUPER_TOKEN_B64" | b64dec }}"
+//	}
+//
+// Example usage:
+//
+//	cfg, err := template.ParseFile("/etc/superclouds/config.json.tmpl", map[string]string{"Env": "staging"})
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+func ParseFile(path string, data interface{}) (*superclouds.Config, error) {
+	tmpl, err := stdtemplate.New(filepath.Base(path)).Funcs(funcs).Option("missingkey=error").ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config template: %w", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render config template %s: %w", path, err)
+	}
+
+	var file superclouds.ConfigFile
+	if err := json.Unmarshal(rendered.Bytes(), &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered config file %s: %w", path, err)
+	}
+
+	if file.CertPath == "" {
+		return nil, fmt.Errorf("missing cert_path in config file %s", path)
+	}
+	if file.KeyPath == "" {
+		return nil, fmt.Errorf("missing key_path in config file %s", path)
+	}
+	if file.Token == "" {
+		return nil, fmt.Errorf("missing token in config file %s", path)
+	}
+
+	opts := []superclouds.Option{superclouds.WithToken(file.Token)}
+	if file.BaseURL != "" {
+		opts = append(opts, superclouds.WithBaseURL(file.BaseURL))
+	}
+	if file.CACertPath != "" {
+		opts = append(opts, superclouds.WithCACert(file.CACertPath))
+	}
+
+	return superclouds.NewConfigWithOptions(file.CertPath, file.KeyPath, opts...)
+}
@@ -0,0 +1,328 @@
+package superclouds_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writeTestCert writes a self-signed client certificate and its key to dir,
+// and returns their paths.
+func writeTestCert(t *testing.T, dir, name string) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certPath, keyPath
+}
+
+// newTestConfig returns a Config without mTLS that sends its requests to srv.
+func newTestConfig(t *testing.T, srv *httptest.Server, opts ...superclouds.Option) *superclouds.Config {
+	t.Helper()
+	opts = append([]superclouds.Option{
+		superclouds.WithBaseURL(srv.URL),
+		superclouds.WithToken("test-token"),
+		superclouds.WithHTTPClient(srv.Client()),
+	}, opts...)
+	cfg, err := superclouds.NewConfigWithOptions("", "", opts...)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	return cfg
+}
+
+// get sends a GET request for path with cfg and returns the response status code.
+func get(t *testing.T, cfg *superclouds.Config, path string) (int, error) {
+	t.Helper()
+	req, err := cfg.NewRequest(context.Background(), http.MethodGet, cfg.URL(path), nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func TestConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCert(t, dir, "client")
+	missingPath := filepath.Join(dir, "missing.pem")
+
+	valid := superclouds.Config{
+		SuperURL:   "https://api.superclouds.example/v1",
+		SuperToken: "token",
+		CertPath:   certPath,
+		KeyPath:    keyPath,
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *superclouds.Config)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(c *superclouds.Config) {}},
+		{name: "without client certificate", mutate: func(c *superclouds.Config) { c.CertPath, c.KeyPath = "", "" }},
+		{name: "missing URL", mutate: func(c *superclouds.Config) { c.SuperURL = "" }, wantErr: true},
+		{name: "relative URL", mutate: func(c *superclouds.Config) { c.SuperURL = "api.superclouds.example" }, wantErr: true},
+		{name: "missing token", mutate: func(c *superclouds.Config) { c.SuperToken = "" }, wantErr: true},
+		{name: "missing key path", mutate: func(c *superclouds.Config) { c.KeyPath = "" }, wantErr: true},
+		{name: "missing cert path", mutate: func(c *superclouds.Config) { c.CertPath = "" }, wantErr: true},
+		{name: "cert file not found", mutate: func(c *superclouds.Config) { c.CertPath = missingPath }, wantErr: true},
+		{name: "key file not found", mutate: func(c *superclouds.Config) { c.KeyPath = missingPath }, wantErr: true},
+		{name: "CA file not found", mutate: func(c *superclouds.Config) { c.CACertPath = missingPath }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewConfigWithParamsValidates(t *testing.T) {
+	certPath, keyPath := writeTestCert(t, t.TempDir(), "client")
+
+	if _, err := superclouds.NewConfigWithParams(certPath, keyPath, "", ""); err == nil {
+		t.Errorf("NewConfigWithParams with an empty token returned no error")
+	}
+	if _, err := superclouds.NewConfigWithParams(certPath, keyPath, "token", ""); err != nil {
+		t.Errorf("NewConfigWithParams: %v", err)
+	}
+}
+
+func TestNewConfigFromEnvPrefix(t *testing.T) {
+	dir := t.TempDir()
+	prodCert, prodKey := writeTestCert(t, dir, "prod")
+	stagingCert, stagingKey := writeTestCert(t, dir, "staging")
+
+	t.Setenv("PROD_CERT", prodCert)
+	t.Setenv("PROD_KEY", prodKey)
+	t.Setenv("PROD_TOKEN", "prod-token")
+	t.Setenv("STAGING_CERT", stagingCert)
+	t.Setenv("STAGING_KEY", stagingKey)
+	t.Setenv("STAGING_TOKEN", "staging-token")
+	t.Setenv("STAGING_URL", "https://staging.superclouds.example/v1")
+
+	prod, err := superclouds.NewConfigFromEnvPrefix("PROD")
+	if err != nil {
+		t.Fatalf("NewConfigFromEnvPrefix(PROD): %v", err)
+	}
+	staging, err := superclouds.NewConfigFromEnvPrefix("STAGING")
+	if err != nil {
+		t.Fatalf("NewConfigFromEnvPrefix(STAGING): %v", err)
+	}
+
+	if prod.SuperToken != "prod-token" || prod.CertPath != prodCert || prod.KeyPath != prodKey {
+		t.Errorf("prod config = %+v", prod)
+	}
+	if prod.SuperURL != "https://api.superclouds.ooo/v1" {
+		t.Errorf("prod SuperURL = %q, want the default URL", prod.SuperURL)
+	}
+	if staging.SuperToken != "staging-token" || staging.CertPath != stagingCert || staging.KeyPath != stagingKey {
+		t.Errorf("staging config = %+v", staging)
+	}
+	if staging.SuperURL != "https://staging.superclouds.example/v1" {
+		t.Errorf("staging SuperURL = %q, want %q", staging.SuperURL, "https://staging.superclouds.example/v1")
+	}
+	if prod.Client == staging.Client {
+		t.Errorf("prod and staging configs share an http.Client")
+	}
+}
+
+func TestNewConfigFromEnvPrefixMissingVariable(t *testing.T) {
+	certPath, keyPath := writeTestCert(t, t.TempDir(), "client")
+	t.Setenv("TEST_CERT", certPath)
+	t.Setenv("TEST_KEY", keyPath)
+	t.Setenv("TEST_TOKEN", "")
+
+	if _, err := superclouds.NewConfigFromEnvPrefix("TEST"); err == nil {
+		t.Errorf("NewConfigFromEnvPrefix without TEST_TOKEN returned no error")
+	}
+}
+
+func TestNewConfigFromFile(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCert(t, dir, "client")
+	path := filepath.Join(dir, "config.json")
+	data := fmt.Sprintf(`{
+		"cert_path": %q,
+		"key_path": %q,
+		"token": "file-token",
+		"base_url": "https://file.superclouds.example/v1"
+	}`, certPath, keyPath)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := superclouds.NewConfigFromFile(path)
+	if err != nil {
+		t.Fatalf("NewConfigFromFile: %v", err)
+	}
+	if cfg.CertPath != certPath || cfg.KeyPath != keyPath {
+		t.Errorf("CertPath, KeyPath = %q, %q, want %q, %q", cfg.CertPath, cfg.KeyPath, certPath, keyPath)
+	}
+	if cfg.SuperToken != "file-token" {
+		t.Errorf("SuperToken = %q, want %q", cfg.SuperToken, "file-token")
+	}
+	if cfg.SuperURL != "https://file.superclouds.example/v1" {
+		t.Errorf("SuperURL = %q, want %q", cfg.SuperURL, "https://file.superclouds.example/v1")
+	}
+	if cfg.Client == nil {
+		t.Errorf("Client is nil")
+	}
+}
+
+func TestNewConfigFromFileMissingToken(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCert(t, dir, "client")
+	path := filepath.Join(dir, "config.json")
+	data := fmt.Sprintf(`{"cert_path": %q, "key_path": %q}`, certPath, keyPath)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	_, err := superclouds.NewConfigFromFile(path)
+	if err == nil || !strings.Contains(err.Error(), "missing token") {
+		t.Errorf("NewConfigFromFile error = %v, want a missing token error", err)
+	}
+}
+
+type tenantKey struct{}
+
+func TestConfigCloneConcurrent(t *testing.T) {
+	cfg, err := superclouds.NewConfigWithOptions("", "",
+		superclouds.WithBaseURL("https://api.superclouds.example/v1"),
+		superclouds.WithToken("parent-token"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clone := cfg.Clone()
+			clone.SuperToken = fmt.Sprintf("tenant-token-%d", i)
+			clone.Client.Timeout = time.Duration(i) * time.Second
+			clone.SetValue(tenantKey{}, i)
+			if clone.Client.Transport != cfg.Client.Transport {
+				t.Errorf("clone does not share the parent's Transport")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if cfg.SuperToken != "parent-token" {
+		t.Errorf("parent SuperToken = %q, want %q", cfg.SuperToken, "parent-token")
+	}
+	if cfg.Client.Timeout != 0 {
+		t.Errorf("parent Client.Timeout = %v, want 0", cfg.Client.Timeout)
+	}
+	if v := cfg.Value(tenantKey{}); v != nil {
+		t.Errorf("parent Value = %v, want nil", v)
+	}
+}
+
+func TestNewConfigWithToken(t *testing.T) {
+	cfg, err := superclouds.NewConfigWithToken("bearer-token")
+	if err != nil {
+		t.Fatalf("NewConfigWithToken: %v", err)
+	}
+	if cfg.CertPath != "" || cfg.KeyPath != "" {
+		t.Errorf("CertPath, KeyPath = %q, %q, want empty", cfg.CertPath, cfg.KeyPath)
+	}
+	if cfg.SuperToken != "bearer-token" {
+		t.Errorf("SuperToken = %q, want %q", cfg.SuperToken, "bearer-token")
+	}
+
+	if _, err := superclouds.NewConfigWithToken(""); err == nil {
+		t.Errorf("NewConfigWithToken with an empty token returned no error")
+	}
+}
+
+func TestNewConfigWithTokenAndCA(t *testing.T) {
+	var authorization string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if len(r.TLS.PeerCertificates) > 0 {
+			t.Errorf("client certificate presented without mTLS")
+		}
+	}))
+	defer srv.Close()
+
+	cfg, err := superclouds.NewConfigWithTokenAndCA("bearer-token", writeServerCA(t, srv))
+	if err != nil {
+		t.Fatalf("NewConfigWithTokenAndCA: %v", err)
+	}
+	if cfg.CertPath != "" || cfg.KeyPath != "" {
+		t.Errorf("CertPath, KeyPath = %q, %q, want empty", cfg.CertPath, cfg.KeyPath)
+	}
+
+	req, err := cfg.NewRequest(context.Background(), http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		t.Fatalf("request to a server signed by the CA failed: %v", err)
+	}
+	resp.Body.Close()
+	if authorization != "Bearer bearer-token" {
+		t.Errorf("Authorization = %q, want %q", authorization, "Bearer bearer-token")
+	}
+
+	if _, err := superclouds.NewConfigWithTokenAndCA("bearer-token", ""); err == nil {
+		t.Errorf("NewConfigWithTokenAndCA without a CA returned no error")
+	}
+}
@@ -0,0 +1,88 @@
+// Package consistency applies optimistic concurrency control to updates, so
+// that concurrent updates of the same user do not overwrite each other. An
+// update is sent with the ETag of the version it was computed from, and is
+// computed again from a fresh read when the API reports that the user changed.
+package consistency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+)
+
+// DefaultMaxAttempts is the number of times UpdateUser tries an update by default.
+const DefaultMaxAttempts = 3
+
+// Option configures UpdateUser.
+type Option func(*options)
+
+type options struct {
+	maxAttempts int
+}
+
+// WithMaxAttempts sets the number of times UpdateUser tries the update
+// before returning superclouds.ErrPreconditionFailed; it defaults to DefaultMaxAttempts.
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// UpdateUser reads the authenticated user, computes an update from it with
+// mutate and sends it with the user's ETag as UpdateUserInput.IfMatch. When
+// the user changed in between, the update is computed again from a fresh
+// read, up to the maximum number of attempts. mutate may be called more than
+// once and should only depend on the user it is given; returning nil skips
+// the update and returns the user as read.
+//
+// Parameters:
+// - ctx: The context for the requests.
+// - client: The client the user is read and updated with, such as a *users.UsersClient.
+// - mutate: Computes the update from the current user.
+// - opts: The options to apply, such as WithMaxAttempts.
+//
+// Returns:
+// - UserOutput: The updated user.
+// - error: superclouds.ErrPreconditionFailed once the attempts are exhausted, or any other error encountered.
+//
+// Example usage:
+//
+//	updatedUser, err := consistency.UpdateUser(context.TODO(), usersClient, func(u *users.User) *users.UpdateUserInput {
+//	    return &users.UpdateUserInput{LastName: strings.ToUpper(u.LastName)}
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update user: %v", err)
+//	}
+func UpdateUser(ctx context.Context, client users.UsersService, mutate func(*users.User) *users.UpdateUserInput, opts ...Option) (*users.UserOutput, error) {
+	o := options{maxAttempts: DefaultMaxAttempts}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var err error
+	for attempt := 0; attempt < max(o.maxAttempts, 1); attempt++ {
+		var current *users.UserOutput
+		current, err = client.GetUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		input := mutate(&current.User)
+		if input == nil {
+			return current, nil
+		}
+		if current.ETag == "" {
+			return nil, fmt.Errorf("failed to update user: the API returned no ETag")
+		}
+		input.IfMatch = current.ETag
+
+		var updated *users.UserOutput
+		updated, err = client.UpdateUser(ctx, input)
+		if !errors.Is(err, superclouds.ErrPreconditionFailed) {
+			return updated, err
+		}
+	}
+	return nil, err
+}
@@ -1,6 +1,17 @@
 package superclouds
 
+import "time"
+
 const (
 	// APIBaseURL is the base URL for the Superclouds API.
 	apiBaseURL = "https://api.superclouds.ooo/v1"
+
+	// DefaultAPIVersion is the Config.APIVersion set by the constructors.
+	DefaultAPIVersion = "v1"
+
+	// DefaultRequestTimeout is the Config.RequestTimeout set by the constructors.
+	DefaultRequestTimeout = 30 * time.Second
+
+	// DefaultMaxResponseBodyBytes is the Config.MaxResponseBodyBytes set by the constructors.
+	DefaultMaxResponseBodyBytes = 10 * 1024 * 1024
 )
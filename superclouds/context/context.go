@@ -0,0 +1,37 @@
+// Package context carries per-request metadata, such as the trace ID and the
+// acting user, through a context.Context so that the SDK can attach it to
+// every outgoing request.
+package context
+
+import (
+	stdcontext "context"
+)
+
+// RequestContext holds the metadata attached to every SDK request made with the context it is stored in.
+// Empty fields are not sent.
+type RequestContext struct {
+	TraceID    string
+	ActorEmail string
+	OrgID      string
+}
+
+type requestContextKey struct{}
+
+// WithRequestContext returns a copy of ctx that carries rc.
+//
+// Example usage:
+//
+//	ctx := supercontext.WithRequestContext(context.TODO(), &supercontext.RequestContext{
+//	    TraceID:    traceID,
+//	    ActorEmail: "admin@example.com",
+//	})
+//	user, err := usersClient.GetUser(ctx)
+func WithRequestContext(ctx stdcontext.Context, rc *RequestContext) stdcontext.Context {
+	return stdcontext.WithValue(ctx, requestContextKey{}, rc)
+}
+
+// RequestContextFrom returns the RequestContext stored in ctx, if any.
+func RequestContextFrom(ctx stdcontext.Context) (*RequestContext, bool) {
+	rc, ok := ctx.Value(requestContextKey{}).(*RequestContext)
+	return rc, ok && rc != nil
+}
@@ -0,0 +1,81 @@
+// Package correlation attaches a correlation ID to outgoing requests, so that
+// a call can be followed across the services it goes through.
+package correlation
+
+import (
+	"context"
+	"github.com/google/uuid"
+	supercontext "github.com/superclouds/super-sdk-go-v1/superclouds/context"
+	"net/http"
+)
+
+// Header is the header that carries the correlation ID.
+const Header = "X-Correlation-ID"
+
+type idKey struct{}
+
+// WithID returns a copy of ctx that carries the correlation ID id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// IDFrom returns the correlation ID of ctx: the ID stored with WithID or,
+// failing that, the trace ID of the RequestContext stored in ctx. It returns
+// "" when ctx carries neither.
+func IDFrom(ctx context.Context) string {
+	if id, ok := ctx.Value(idKey{}).(string); ok && id != "" {
+		return id
+	}
+	if rc, ok := supercontext.RequestContextFrom(ctx); ok {
+		return rc.TraceID
+	}
+	return ""
+}
+
+// NewRoundTripper returns an http.RoundTripper that sets the X-Correlation-ID
+// header of each request that does not have one to the correlation ID of the
+// request context, see IDFrom, or to a new ID from generator. When generator
+// is nil, random UUIDs are used; when next is nil, http.DefaultTransport is used.
+// Installed with superclouds.WithMiddleware, it wraps the SDK's transport, so
+// that retries of a request keep its correlation ID.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+//	        return correlation.NewRoundTripper(nil, next)
+//	    }),
+//	)
+func NewRoundTripper(generator func() string, next http.RoundTripper) http.RoundTripper {
+	if generator == nil {
+		generator = uuid.NewString
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &roundTripper{generator: generator, next: next}
+}
+
+// roundTripper sets the correlation ID header.
+type roundTripper struct {
+	generator func() string
+	next      http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(Header) != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	id := IDFrom(req.Context())
+	if id == "" {
+		id = t.generator()
+	}
+
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set(Header, id)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,181 @@
+// Package cost estimates the cost of API calls on the Superclouds plans that
+// charge per call, from a pricing table cached with the SDK, so that callers
+// can budget calls before making them.
+package cost
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/pagination"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/http"
+	"strconv"
+)
+
+//go:embed pricing/pricing.json
+var pricingFS embed.FS
+
+// Cost is the estimated cost of one or more API calls.
+// PriceMicros is the price in millionths of Currency.
+type Cost struct {
+	Units       int
+	PriceMicros int64
+	Currency    string
+}
+
+// Pricing is a pricing table. Operations maps the operations named with
+// superclouds.WithOperation, as "resource.method", to the units they cost
+// per call; operations missing from it cost nothing.
+type Pricing struct {
+	Currency        string `json:"currency"`
+	UnitPriceMicros int64  `json:"unit_price_micros"`
+	// ListPageSize is the number of users that a list call returns per unit.
+	ListPageSize int `json:"list_page_size"`
+	// ImportUnitsPerRow is the cost of each row of a user import.
+	ImportUnitsPerRow int            `json:"import_units_per_row"`
+	Operations        map[string]int `json:"operations"`
+}
+
+// DefaultPricing returns the pricing table cached with the SDK.
+func DefaultPricing() *Pricing {
+	data, err := pricingFS.ReadFile("pricing/pricing.json")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read pricing table: %v", err))
+	}
+	var p Pricing
+	if err := json.Unmarshal(data, &p); err != nil {
+		panic(fmt.Sprintf("invalid pricing table: %v", err))
+	}
+	return &p
+}
+
+// Estimator estimates the cost of API calls from a pricing table.
+type Estimator struct {
+	pricing *Pricing
+}
+
+// NewEstimator creates an Estimator that uses pricing, or DefaultPricing when pricing is nil.
+//
+// Example usage:
+//
+//	estimator := cost.NewEstimator(nil)
+//	c := estimator.EstimateImportUsers(len(rows))
+//	log.Printf("Importing will cost %d %s micros", c.PriceMicros, c.Currency)
+func NewEstimator(pricing *Pricing) *Estimator {
+	if pricing == nil {
+		pricing = DefaultPricing()
+	}
+	return &Estimator{pricing: pricing}
+}
+
+// cost returns the Cost of units.
+func (e *Estimator) cost(units int) Cost {
+	return Cost{
+		Units:       units,
+		PriceMicros: int64(units) * e.pricing.UnitPriceMicros,
+		Currency:    e.pricing.Currency,
+	}
+}
+
+// Estimate returns the cost of one call of the operation method of resource,
+// such as "users" and "get".
+func (e *Estimator) Estimate(resource, method string) Cost {
+	return e.cost(e.pricing.Operations[resource+"."+method])
+}
+
+// EstimateListUsers returns the cost of a ListUsers call with input.
+// Pages larger than the ListPageSize of the pricing table cost the units of
+// a list call once per ListPageSize users.
+//
+// Parameters:
+// - input: The input of the ListUsers call; nil is the default page.
+//
+// Returns:
+// - Cost: The estimated cost of the call.
+//
+// Example usage:
+//
+//	c := estimator.EstimateListUsers(&users.ListUsersInput{Size: 500})
+func (e *Estimator) EstimateListUsers(input *users.ListUsersInput) Cost {
+	size := 0
+	if input != nil {
+		size = input.Size
+		if input.PageToken != "" {
+			if _, tokenSize, _, err := pagination.DecodeToken(input.PageToken); err == nil {
+				size = tokenSize
+			}
+		}
+	}
+	return e.estimateList(size)
+}
+
+// estimateList returns the cost of a list call of a page of size users.
+func (e *Estimator) estimateList(size int) Cost {
+	units := 1
+	if size > 0 && e.pricing.ListPageSize > 0 {
+		units = (size + e.pricing.ListPageSize - 1) / e.pricing.ListPageSize
+	}
+	return e.cost(e.pricing.Operations["users.list"] * units)
+}
+
+// EstimateImportUsers returns the cost of importing rowCount users.
+//
+// Parameters:
+// - rowCount: The number of users to import.
+//
+// Returns:
+// - Cost: The estimated cost of the import.
+//
+// Example usage:
+//
+//	c := estimator.EstimateImportUsers(10000)
+func (e *Estimator) EstimateImportUsers(rowCount int) Cost {
+	if rowCount < 0 {
+		rowCount = 0
+	}
+	return e.cost(rowCount * e.pricing.ImportUnitsPerRow)
+}
+
+// WithCostEstimator makes the SDK log the cost that e estimates for each
+// call, with the Config's Logger, before sending it.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    cost.WithCostEstimator(cost.NewEstimator(nil)),
+//	)
+func WithCostEstimator(e *Estimator) superclouds.Option {
+	return func(c *superclouds.Config) {
+		superclouds.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return &costRoundTripper{next: next, estimator: e, config: c}
+		})(c)
+	}
+}
+
+// costRoundTripper logs the estimated cost of each call.
+type costRoundTripper struct {
+	next      http.RoundTripper
+	estimator *Estimator
+	config    *superclouds.Config
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *costRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if resource, method, ok := superclouds.OperationFrom(req.Context()); ok {
+		c := t.estimator.Estimate(resource, method)
+		if resource == "users" && method == "list" {
+			size, _ := strconv.Atoi(req.URL.Query().Get("size"))
+			c = t.estimator.estimateList(size)
+		}
+		t.config.Logger().Info("estimated cost of call",
+			"operation", resource+"."+method,
+			"units", c.Units,
+			"price_micros", c.PriceMicros,
+			"currency", c.Currency,
+		)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,57 @@
+// Package cursor provides the page type and iteration helper shared by the SDK's cursor-paginated list methods.
+package cursor
+
+import (
+	"context"
+)
+
+// Page is a single page of a cursor-paginated list.
+// NextCursor is passed back to the list method to fetch the following page
+// and is empty on the last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+	TotalCount int    `json:"total_count"`
+}
+
+// ForEach calls fetch with an empty cursor, then with each NextCursor it returns,
+// and calls fn for every item of every page. It stops at the first error
+// returned by fetch or fn, when ctx is done, or after the last page.
+//
+// Example usage:
+//
+//	err := cursor.ForEach(ctx, func(next string) (*cursor.Page[users.User], error) {
+//	    output, err := usersClient.ListUsers(ctx, &users.ListUsersInput{Size: 50, Cursor: next})
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return &output.Page, nil
+//	}, func(user users.User) error {
+//	    log.Printf("User: %v", user)
+//	    return nil
+//	})
+func ForEach[T any](ctx context.Context, fetch func(cursor string) (*Page[T], error), fn func(T) error) error {
+	next := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := fetch(next)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range page.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if !page.HasMore || page.NextCursor == "" {
+			return nil
+		}
+		next = page.NextCursor
+	}
+}
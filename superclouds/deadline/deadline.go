@@ -0,0 +1,90 @@
+// Package deadline applies default deadlines to SDK requests by category:
+// reads, writes and bulk operations.
+package deadline
+
+import (
+	"context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CategoryDeadlines holds the default deadline of each request category.
+// Bulk operations are those whose operation method starts with "bulk", such
+// as users.bulk_create; other GET and HEAD requests are reads, and the
+// remaining requests are writes. A zero duration leaves the category alone.
+type CategoryDeadlines struct {
+	ReadDeadline  time.Duration
+	WriteDeadline time.Duration
+	BulkDeadline  time.Duration
+}
+
+// WithCategoryDeadlines applies the deadline of its category to each request
+// whose context has no deadline. As the deadline is set before the SDK's own
+// handling, it replaces Config.RequestTimeout, so BulkDeadline may exceed it.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    deadline.WithCategoryDeadlines(deadline.CategoryDeadlines{
+//	        ReadDeadline:  5 * time.Second,
+//	        WriteDeadline: 15 * time.Second,
+//	        BulkDeadline:  2 * time.Minute,
+//	    }),
+//	)
+func WithCategoryDeadlines(d CategoryDeadlines) superclouds.Option {
+	return superclouds.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return &roundTripper{next: next, deadlines: d}
+	})
+}
+
+// For returns the deadline of the category of req.
+func (d CategoryDeadlines) For(req *http.Request) time.Duration {
+	if _, method, ok := superclouds.OperationFrom(req.Context()); ok && strings.HasPrefix(method, "bulk") {
+		return d.BulkDeadline
+	}
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return d.ReadDeadline
+	}
+	return d.WriteDeadline
+}
+
+// roundTripper sets the category deadline on requests.
+type roundTripper struct {
+	next      http.RoundTripper
+	deadlines CategoryDeadlines
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.deadlines.For(req)
+	if _, ok := req.Context().Deadline(); ok || timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.next.RoundTrip(req.Clone(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The deadline also covers reading the body, so it is released when the body is closed.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose is a response body that cancels the request context when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
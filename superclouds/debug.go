@@ -0,0 +1,201 @@
+package superclouds
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+)
+
+// defaultMaxDebugBodyBytes is the number of body bytes written by the debug
+// transport when Config.MaxDebugBodyBytes is not set.
+const defaultMaxDebugBodyBytes = 4096
+
+// WithDebugWriter writes every request and response, headers and body, to w.
+// The Authorization header value is replaced with [REDACTED], and bodies are
+// truncated at Config.MaxDebugBodyBytes. It is meant for debugging only: the
+// output may still contain personal data, unless it is redacted with
+// WithDebugBodyFilter and WithDebugIdentityRedaction.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithDebugWriter(os.Stderr),
+//	)
+func WithDebugWriter(w io.Writer) Option {
+	return func(c *Config) {
+		c.debugWriter = w
+	}
+}
+
+// WithDebugBodyFilter makes the debug transport, see WithDebugWriter, pass
+// each request and response body through filter before writing it, such as
+// to redact personal data. filter receives the start of the body, which is
+// cut at Config.MaxDebugBodyBytes plus one byte when the body is longer.
+// Passing nil writes bodies as they are.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithDebugWriter(os.Stderr),
+//	    superclouds.WithDebugBodyFilter(func(body []byte) []byte {
+//	        return emailPattern.ReplaceAll(body, []byte("[EMAIL]"))
+//	    }),
+//	)
+func WithDebugBodyFilter(filter func([]byte) []byte) Option {
+	return func(c *Config) {
+		c.debugBodyFilter = filter
+	}
+}
+
+// WithDebugIdentityRedaction makes the debug transport, see WithDebugWriter,
+// replace the values of the query parameters and of the identity headers,
+// such as X-Actor-Email, with [REDACTED] when enabled is true. Query
+// parameters such as the email of DeleteUser identify users, so the request
+// line is otherwise written as it is sent.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithDebugWriter(os.Stderr),
+//	    superclouds.WithDebugIdentityRedaction(true),
+//	)
+func WithDebugIdentityRedaction(enabled bool) Option {
+	return func(c *Config) {
+		c.debugRedactIdentity = enabled
+	}
+}
+
+// identityHeaders are the request headers that identify a person, see WithDebugIdentityRedaction.
+var identityHeaders = []string{actorEmailHeader}
+
+// debugRoundTripper wraps a RoundTripper and dumps the traffic to a writer.
+type debugRoundTripper struct {
+	next         http.RoundTripper
+	maxBodyBytes int
+	// filter rewrites the bodies written, see WithDebugBodyFilter.
+	filter func([]byte) []byte
+	// redactIdentity hides query values and identity headers, see WithDebugIdentityRedaction.
+	redactIdentity bool
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// RoundTrip implements http.RoundTripper.
+func (d *debugRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	d.write(d.dumpRequest(req))
+
+	resp, err := d.next.RoundTrip(req)
+	if err != nil {
+		d.write([]byte(fmt.Sprintf("request failed: %v\n\n", err)))
+		return nil, err
+	}
+
+	d.write(d.dumpResponse(resp))
+	return resp, nil
+}
+
+// dumpRequest returns the headers of req with the Authorization value redacted,
+// followed by the start of its body. The body of req is left unread.
+func (d *debugRoundTripper) dumpRequest(req *http.Request) []byte {
+	redacted := req.Clone(req.Context())
+	if redacted.Header.Get("Authorization") != "" {
+		redacted.Header.Set("Authorization", "[REDACTED]")
+	}
+	if d.redactIdentity {
+		for _, name := range identityHeaders {
+			if redacted.Header.Get(name) != "" {
+				redacted.Header.Set(name, "[REDACTED]")
+			}
+		}
+		redacted.URL.RawQuery = redactQuery(redacted.URL.RawQuery)
+	}
+	dump, err := httputil.DumpRequestOut(redacted, false)
+	if err != nil {
+		return []byte(fmt.Sprintf("failed to dump request: %v\n\n", err))
+	}
+
+	var body []byte
+	switch {
+	case req.Body == nil || req.Body == http.NoBody:
+	case req.GetBody == nil:
+		// Reading the body would consume it before it is sent.
+		body = []byte("[body not shown]")
+	default:
+		rc, err := req.GetBody()
+		if err != nil {
+			body = []byte(fmt.Sprintf("[failed to read body: %v]", err))
+			break
+		}
+		body = d.truncate(d.readHead(rc))
+		rc.Close()
+	}
+	return append(append(dump, body...), '\n', '\n')
+}
+
+// redactQuery returns the query string rawQuery with every value replaced with [REDACTED].
+// The parameter names are kept, in their order.
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		name, _, _ := strings.Cut(param, "=")
+		params[i] = name + "=[REDACTED]"
+	}
+	return strings.Join(params, "&")
+}
+
+// dumpResponse returns the headers of resp followed by the start of its body.
+// The body bytes read are put back, so that the caller can still read the whole body.
+func (d *debugRoundTripper) dumpResponse(resp *http.Response) []byte {
+	dump, err := httputil.DumpResponse(resp, false)
+	if err != nil {
+		return []byte(fmt.Sprintf("failed to dump response: %v\n\n", err))
+	}
+
+	if resp.Body != nil && resp.Body != http.NoBody {
+		head := d.readHead(resp.Body)
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+		dump = append(dump, d.truncate(head)...)
+	}
+	return append(dump, '\n', '\n')
+}
+
+// readHead reads one byte more than the body limit from r, so that
+// truncate can tell whether the body is longer than the limit.
+func (d *debugRoundTripper) readHead(r io.Reader) []byte {
+	head, _ := io.ReadAll(io.LimitReader(r, int64(d.maxBodyBytes)+1))
+	return head
+}
+
+// truncate returns head, passed through the filter, cut at the body limit,
+// with a marker when it is longer.
+func (d *debugRoundTripper) truncate(head []byte) []byte {
+	if d.filter != nil && len(head) > 0 {
+		// The filter gets a copy, as dumpResponse puts head back into the body.
+		head = d.filter(append([]byte(nil), head...))
+	}
+	if len(head) <= d.maxBodyBytes {
+		return head
+	}
+	return append(head[:d.maxBodyBytes:d.maxBodyBytes], "... [truncated]"...)
+}
+
+// write writes one dump to the writer. Dumps of concurrent requests are not interleaved.
+func (d *debugRoundTripper) write(p []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.w.Write(p)
+}
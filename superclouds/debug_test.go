@@ -0,0 +1,62 @@
+package superclouds_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugWriterRedactsAuthorization(t *testing.T) {
+	responseBody := strings.Repeat("r", 100)
+	var receivedBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		io.WriteString(w, responseBody)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	cfg := newTestConfig(t, srv,
+		superclouds.WithToken("secret-token"),
+		superclouds.WithDebugWriter(&out),
+		func(c *superclouds.Config) { c.MaxDebugBodyBytes = 10 },
+	)
+
+	requestBody := strings.Repeat("q", 100)
+	req, err := cfg.NewRequest(context.Background(), http.MethodPost, cfg.URL("/users"), strings.NewReader(requestBody))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	dump := out.String()
+	if strings.Contains(dump, "secret-token") {
+		t.Errorf("debug output contains the token:\n%s", dump)
+	}
+	if !strings.Contains(dump, "Authorization: [REDACTED]") {
+		t.Errorf("debug output has no redacted Authorization header:\n%s", dump)
+	}
+	if !strings.Contains(dump, strings.Repeat("q", 10)+"... [truncated]") || strings.Contains(dump, strings.Repeat("q", 11)) {
+		t.Errorf("request body not truncated at 10 bytes:\n%s", dump)
+	}
+	if !strings.Contains(dump, strings.Repeat("r", 10)+"... [truncated]") || strings.Contains(dump, strings.Repeat("r", 11)) {
+		t.Errorf("response body not truncated at 10 bytes:\n%s", dump)
+	}
+
+	if string(receivedBody) != requestBody {
+		t.Errorf("server received %d body bytes, want %d", len(receivedBody), len(requestBody))
+	}
+	if string(body) != responseBody {
+		t.Errorf("caller read %d body bytes, want %d", len(body), len(responseBody))
+	}
+}
@@ -0,0 +1,269 @@
+// Package diagnostics collects the state of an SDK configuration into a
+// report that can be attached to a support request.
+//
+// Secrets are never included: the token is masked and only its expiry is reported.
+package diagnostics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// apiVersionHeader is the response header in which the API reports its version.
+const apiVersionHeader = "X-API-Version"
+
+// DiagnosticsBundle is the state collected by Collect.
+// Fields that could not be determined are left at their zero value, with the
+// reason recorded in Errors.
+type DiagnosticsBundle struct {
+	// CollectedAt is when the bundle was collected.
+	CollectedAt time.Time
+	// Config summarises the configuration, with the token masked.
+	Config ConfigSummary
+	// CertExpiry is when the client certificate expires; it is zero for bearer-only configs.
+	CertExpiry time.Time
+	// APIVersion is the version reported by the API, or the APIVersion of the Config.
+	APIVersion string
+	// TokenExpiry is the expiry of SuperToken if it is a JWT with an exp claim.
+	TokenExpiry time.Time
+	// Ping is the result of a request to the API.
+	Ping PingResult
+	// Logs are the last log entries of the Config, if its Logger is a Recorder.
+	Logs []LogEntry
+	// Errors lists what could not be collected.
+	Errors []string
+}
+
+// ConfigSummary is the part of a Config included in a DiagnosticsBundle.
+type ConfigSummary struct {
+	SuperURL             string
+	CertPath             string
+	KeyPath              string
+	CACertPath           string
+	Token                string
+	RequestTimeout       time.Duration
+	MaxRequestBodyBytes  int64
+	MaxResponseBodyBytes int64
+}
+
+// PingResult is the outcome of the request sent by Collect.
+// Any response, whatever its status, shows that the API is reachable.
+type PingResult struct {
+	StatusCode int
+	Latency    time.Duration
+	Err        error
+}
+
+// Collect gathers the diagnostic state of cfg. It sends a single request to
+// the API; failing to reach it is recorded in the bundle rather than returned.
+//
+// Parameters:
+// - ctx: The context for the ping request.
+// - cfg: The configuration to diagnose.
+//
+// Returns:
+// - *DiagnosticsBundle: The collected state.
+// - error: An error if cfg is nil.
+//
+// Example usage:
+//
+//	bundle, err := diagnostics.Collect(ctx, cfg)
+//	if err != nil {
+//	    log.Fatalf("Failed to collect diagnostics: %v", err)
+//	}
+//	bundle.WriteTo(os.Stdout)
+func Collect(ctx context.Context, cfg *superclouds.Config) (*DiagnosticsBundle, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("missing config")
+	}
+
+	b := &DiagnosticsBundle{
+		CollectedAt: time.Now(),
+		Config: ConfigSummary{
+			SuperURL:             cfg.SuperURL,
+			CertPath:             cfg.CertPath,
+			KeyPath:              cfg.KeyPath,
+			CACertPath:           cfg.CACertPath,
+			Token:                maskToken(cfg.SuperToken),
+			RequestTimeout:       cfg.RequestTimeout,
+			MaxRequestBodyBytes:  cfg.MaxRequestBodyBytes,
+			MaxResponseBodyBytes: cfg.MaxResponseBodyBytes,
+		},
+	}
+
+	if cfg.CertPath != "" {
+		expiry, err := certExpiry(cfg.CertPath, cfg.KeyPath)
+		if err != nil {
+			b.Errors = append(b.Errors, fmt.Sprintf("certificate: %v", err))
+		}
+		b.CertExpiry = expiry
+	}
+
+	if cfg.SuperToken != "" {
+		expiry, err := tokenExpiry(cfg.SuperToken)
+		if err != nil {
+			b.Errors = append(b.Errors, fmt.Sprintf("token: %v", err))
+		}
+		b.TokenExpiry = expiry
+	}
+
+	var version string
+	b.Ping, version = ping(ctx, cfg)
+	if version == "" {
+		version = cfg.APIVersion
+	}
+	b.APIVersion = version
+
+	if r, ok := cfg.Logger().(*Recorder); ok {
+		b.Logs = r.Entries()
+	}
+
+	return b, nil
+}
+
+// maskToken keeps the first four characters of token, which is enough to tell tokens apart.
+func maskToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	if len(token) <= 8 {
+		return "****"
+	}
+	return token[:4] + "****"
+}
+
+// certExpiry returns the expiry of the certificate in certPath.
+func certExpiry(certPath, keyPath string) (time.Time, error) {
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to load key pair: %w", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	return cert.NotAfter, nil
+}
+
+// tokenExpiry returns the exp claim of token if it is a JWT.
+// Opaque tokens have no expiry and yield the zero time without error.
+func tokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode JWT payload: %w", err)
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse JWT claims: %w", err)
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
+
+// ping requests the first user and returns the result, along with the API version reported by the response.
+func ping(ctx context.Context, cfg *superclouds.Config) (PingResult, string) {
+	if cfg.Client == nil {
+		return PingResult{Err: fmt.Errorf("config has no HTTP client")}, ""
+	}
+	req, err := cfg.NewRequest(ctx, http.MethodGet, cfg.URL("/users?size=1"), nil)
+	if err != nil {
+		return PingResult{Err: err}, ""
+	}
+
+	start := time.Now()
+	resp, err := cfg.Client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return PingResult{Latency: latency, Err: err}, ""
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+	return PingResult{StatusCode: resp.StatusCode, Latency: latency}, resp.Header.Get(apiVersionHeader)
+}
+
+// WriteTo writes a human-readable report of the bundle to w.
+// It implements io.WriterTo, so it also returns the number of bytes written.
+//
+// Example usage:
+//
+//	if _, err := bundle.WriteTo(os.Stdout); err != nil {
+//	    log.Fatalf("Failed to write diagnostics: %v", err)
+//	}
+func (b *DiagnosticsBundle) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Superclouds SDK diagnostics (%s)\n", b.CollectedAt.Format(time.RFC3339))
+
+	sb.WriteString("\nConfiguration\n")
+	fmt.Fprintf(&sb, "  Base URL:                %s\n", orNone(b.Config.SuperURL))
+	fmt.Fprintf(&sb, "  Certificate:             %s\n", orNone(b.Config.CertPath))
+	fmt.Fprintf(&sb, "  Key:                     %s\n", orNone(b.Config.KeyPath))
+	fmt.Fprintf(&sb, "  CA certificate:          %s\n", orNone(b.Config.CACertPath))
+	fmt.Fprintf(&sb, "  Token:                   %s\n", orNone(b.Config.Token))
+	fmt.Fprintf(&sb, "  Request timeout:         %s\n", b.Config.RequestTimeout)
+	fmt.Fprintf(&sb, "  Max request body bytes:  %d\n", b.Config.MaxRequestBodyBytes)
+	fmt.Fprintf(&sb, "  Max response body bytes: %d\n", b.Config.MaxResponseBodyBytes)
+
+	sb.WriteString("\nCredentials\n")
+	fmt.Fprintf(&sb, "  Certificate expires:     %s\n", formatExpiry(b.CertExpiry, b.CollectedAt))
+	fmt.Fprintf(&sb, "  Token expires:           %s\n", formatExpiry(b.TokenExpiry, b.CollectedAt))
+
+	sb.WriteString("\nAPI\n")
+	fmt.Fprintf(&sb, "  Version:                 %s\n", orNone(b.APIVersion))
+	if b.Ping.Err != nil {
+		fmt.Fprintf(&sb, "  Ping:                    failed after %s: %v\n", b.Ping.Latency, b.Ping.Err)
+	} else {
+		fmt.Fprintf(&sb, "  Ping:                    HTTP %d in %s\n", b.Ping.StatusCode, b.Ping.Latency)
+	}
+
+	if len(b.Errors) > 0 {
+		sb.WriteString("\nErrors\n")
+		for _, e := range b.Errors {
+			fmt.Fprintf(&sb, "  %s\n", e)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\nRecent log entries (%d)\n", len(b.Logs))
+	for _, entry := range b.Logs {
+		fmt.Fprintf(&sb, "  %s\n", entry)
+	}
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// orNone returns s, or "(none)" if it is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// formatExpiry formats t along with the time left before it, relative to now.
+func formatExpiry(t, now time.Time) string {
+	if t.IsZero() {
+		return "(unknown)"
+	}
+	left := t.Sub(now).Round(time.Minute)
+	if left < 0 {
+		return fmt.Sprintf("%s (expired %s ago)", t.Format(time.RFC3339), -left)
+	}
+	return fmt.Sprintf("%s (in %s)", t.Format(time.RFC3339), left)
+}
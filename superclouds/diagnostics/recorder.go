@@ -0,0 +1,103 @@
+package diagnostics
+
+import (
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxLogEntries is the number of log entries kept by a Recorder.
+const MaxLogEntries = 100
+
+// LogEntry is a log message kept by a Recorder.
+type LogEntry struct {
+	Time    time.Time
+	Level   string
+	Message string
+	KVs     []interface{}
+}
+
+// String formats the entry on a single line.
+func (e LogEntry) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %-5s %s", e.Time.Format(time.RFC3339), e.Level, e.Message)
+	for i := 0; i+1 < len(e.KVs); i += 2 {
+		fmt.Fprintf(&sb, " %v=%v", e.KVs[i], e.KVs[i+1])
+	}
+	return sb.String()
+}
+
+// Recorder is a superclouds.Logger that keeps the last MaxLogEntries
+// messages for Collect, and forwards every message to another Logger.
+type Recorder struct {
+	next superclouds.Logger
+
+	mu      sync.Mutex
+	entries []LogEntry
+	// start is the index of the oldest entry once entries is full.
+	start int
+}
+
+// NewRecorder returns a Recorder that forwards messages to next, which may be nil.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithLogger(diagnostics.NewRecorder(myLogger)),
+//	)
+func NewRecorder(next superclouds.Logger) *Recorder {
+	if next == nil {
+		next = superclouds.NopLogger{}
+	}
+	return &Recorder{next: next}
+}
+
+// Info implements superclouds.Logger.
+func (r *Recorder) Info(msg string, kvs ...interface{}) {
+	r.record("INFO", msg, kvs)
+	r.next.Info(msg, kvs...)
+}
+
+// Debug implements superclouds.Logger.
+func (r *Recorder) Debug(msg string, kvs ...interface{}) {
+	r.record("DEBUG", msg, kvs)
+	r.next.Debug(msg, kvs...)
+}
+
+// Warn implements superclouds.Logger.
+func (r *Recorder) Warn(msg string, kvs ...interface{}) {
+	r.record("WARN", msg, kvs)
+	r.next.Warn(msg, kvs...)
+}
+
+// Error implements superclouds.Logger.
+func (r *Recorder) Error(msg string, kvs ...interface{}) {
+	r.record("ERROR", msg, kvs)
+	r.next.Error(msg, kvs...)
+}
+
+// record adds an entry, replacing the oldest one once MaxLogEntries are kept.
+func (r *Recorder) record(level, msg string, kvs []interface{}) {
+	entry := LogEntry{Time: time.Now(), Level: level, Message: msg, KVs: kvs}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) < MaxLogEntries {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	r.entries[r.start] = entry
+	r.start = (r.start + 1) % MaxLogEntries
+}
+
+// Entries returns the kept entries, oldest first.
+func (r *Recorder) Entries() []LogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]LogEntry, 0, len(r.entries))
+	entries = append(entries, r.entries[r.start:]...)
+	return append(entries, r.entries[:r.start]...)
+}
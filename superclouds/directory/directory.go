@@ -0,0 +1,175 @@
+package directory
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"net/url"
+	"time"
+)
+
+// Client provides methods to configure and run LDAP/Active Directory synchronisation through the Superclouds API.
+type Client struct {
+	config *superclouds.Config
+}
+
+// NewClient creates a new directory Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	directoryClient := directory.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg}
+}
+
+// DirectorySyncConfig describes how users and groups are synchronised from an LDAP/AD server.
+type DirectorySyncConfig struct {
+	LDAPUrl             string `json:"ldap_url"`
+	BindDN              string `json:"bind_dn"`
+	UserSearchBase      string `json:"user_search_base"`
+	GroupSearchBase     string `json:"group_search_base"`
+	SyncIntervalMinutes int    `json:"sync_interval_minutes"`
+}
+
+// UpdateDirSyncInput defines the input parameters for the UpdateSyncConfig method.
+// Empty fields are not changed. BindPassword is write-only and never returned by the API.
+type UpdateDirSyncInput struct {
+	LDAPUrl             string `json:"ldap_url,omitempty"`
+	BindDN              string `json:"bind_dn,omitempty"`
+	BindPassword        string `json:"bind_password,omitempty"`
+	UserSearchBase      string `json:"user_search_base,omitempty"`
+	GroupSearchBase     string `json:"group_search_base,omitempty"`
+	SyncIntervalMinutes int    `json:"sync_interval_minutes,omitempty"`
+}
+
+// Sync job states reported in the Status field of SyncJob.
+const (
+	SyncStatusPending   = "pending"
+	SyncStatusRunning   = "running"
+	SyncStatusSucceeded = "succeeded"
+	SyncStatusFailed    = "failed"
+)
+
+// SyncJob describes a directory synchronisation run.
+// CompletedAt is nil while the job is pending or running.
+type SyncJob struct {
+	ID           string     `json:"id"`
+	Status       string     `json:"status"`
+	StartedAt    time.Time  `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	UsersCreated int        `json:"users_created"`
+	UsersUpdated int        `json:"users_updated"`
+	UsersDeleted int        `json:"users_deleted"`
+	Error        string     `json:"error"`
+}
+
+// GetSyncConfig retrieves the directory synchronisation configuration.
+//
+// Parameters:
+// - ctx: The context for the request.
+//
+// Returns:
+// - DirectorySyncConfig: The current synchronisation configuration.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	syncConfig, err := directoryClient.GetSyncConfig(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get sync config: %v", err)
+//	}
+//	log.Printf("Sync Config: %v", syncConfig)
+func (c *Client) GetSyncConfig(ctx context.Context) (*DirectorySyncConfig, error) {
+	apiResponse, err := generic.Get[generic.Response[DirectorySyncConfig]](ctx, c.config, "/directory/sync-config", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync config: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// UpdateSyncConfig updates the directory synchronisation configuration.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := directoryClient.UpdateSyncConfig(context.TODO(), &directory.UpdateDirSyncInput{
+//	    LDAPUrl:             "ldaps://ldap.example.com:636",
+//	    SyncIntervalMinutes: 60,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update sync config: %v", err)
+//	}
+//	log.Println("Updated Sync Config")
+func (c *Client) UpdateSyncConfig(ctx context.Context, input *UpdateDirSyncInput) error {
+	if _, err := generic.Patch[UpdateDirSyncInput, struct{}](ctx, c.config, "/directory/sync-config", input); err != nil {
+		return fmt.Errorf("failed to update sync config: %w", err)
+	}
+
+	return nil
+}
+
+// TriggerSync starts a directory synchronisation run immediately.
+//
+// Parameters:
+// - ctx: The context for the request.
+//
+// Returns:
+// - SyncJob: The started job; poll GetSyncStatus with its ID to follow progress.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	job, err := directoryClient.TriggerSync(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to trigger sync: %v", err)
+//	}
+//	log.Printf("Sync Job: %v", job)
+func (c *Client) TriggerSync(ctx context.Context) (*SyncJob, error) {
+	apiResponse, err := generic.Post[struct{}, generic.Response[SyncJob]](ctx, c.config, "/directory/sync-jobs", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger sync: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// GetSyncStatus retrieves the status of a directory synchronisation run.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - jobID: The ID of the job returned by TriggerSync.
+//
+// Returns:
+// - SyncJob: The job details.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	job, err := directoryClient.GetSyncStatus(context.TODO(), job.ID)
+//	if err != nil {
+//	    log.Fatalf("Failed to get sync status: %v", err)
+//	}
+//	log.Printf("Sync Status: %v", job.Status)
+func (c *Client) GetSyncStatus(ctx context.Context, jobID string) (*SyncJob, error) {
+	if jobID == "" {
+		return nil, fmt.Errorf("job ID is required")
+	}
+
+	apiResponse, err := generic.Get[generic.Response[SyncJob]](ctx, c.config, "/directory/sync-jobs/"+url.PathEscape(jobID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
@@ -0,0 +1,136 @@
+package superclouds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NotFoundError is returned when the API responds with HTTP 404 because the
+// requested resource does not exist. Identifier is empty when the request
+// did not name the resource, as for the authenticated user.
+type NotFoundError struct {
+	ResourceType string
+	Identifier   string
+}
+
+// Error implements error.
+func (e NotFoundError) Error() string {
+	resourceType := e.ResourceType
+	if resourceType == "" {
+		resourceType = "resource"
+	}
+	if e.Identifier == "" {
+		return fmt.Sprintf("%s not found", resourceType)
+	}
+	return fmt.Sprintf("%s %q not found", resourceType, e.Identifier)
+}
+
+// ErrPreconditionFailed is returned when the API responds with HTTP 412
+// because the resource changed since the ETag sent in the If-Match header
+// was read, such as by UpdateUser with UpdateUserInput.IfMatch set.
+var ErrPreconditionFailed = errors.New("precondition failed: the resource was modified")
+
+// WithErrorTranslator passes the errors built from API error responses through
+// translate before they are returned, for example to localize their messages.
+// translate should wrap the error it is given, so that errors.As still finds it.
+func WithErrorTranslator(translate func(error) error) Option {
+	return func(c *Config) {
+		c.errorTranslator = translate
+	}
+}
+
+type resourceKey struct{}
+
+type resource struct {
+	resourceType string
+	identifier   string
+}
+
+// WithResource returns a copy of ctx that names the resource a request
+// targets, such as resource type "user" and the user's ID. Clients set it
+// before making their requests, so that a 404 response yields a
+// NotFoundError naming the resource.
+func WithResource(ctx context.Context, resourceType, identifier string) context.Context {
+	return context.WithValue(ctx, resourceKey{}, resource{resourceType: resourceType, identifier: identifier})
+}
+
+// NewNotFoundError returns the NotFoundError for a request made with ctx,
+// naming the resource set with WithResource or, failing that, the resource
+// of the operation set with WithOperation.
+func NewNotFoundError(ctx context.Context) NotFoundError {
+	if r, ok := ctx.Value(resourceKey{}).(resource); ok {
+		return NotFoundError{ResourceType: r.resourceType, Identifier: r.identifier}
+	}
+	resourceType, _, _ := OperationFrom(ctx)
+	return NotFoundError{ResourceType: resourceType}
+}
+
+// ConflictError is returned when the API responds with HTTP 409 because the
+// request conflicts with an existing resource, such as a user with the same
+// email. ExistingID is the ID of that resource, when the API reports it.
+type ConflictError struct {
+	Message    string `json:"error"`
+	ExistingID string `json:"existing_id"`
+}
+
+// Error implements error.
+func (e ConflictError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = "conflict"
+	}
+	if e.ExistingID == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s (existing ID %q)", msg, e.ExistingID)
+}
+
+// FieldError describes why the API rejected the value of one input field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when the API responds with HTTP 422 because
+// the input failed server-side validation. Fields holds one entry per
+// rejected field.
+type ValidationError struct {
+	Fields []FieldError `json:"errors"`
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return "validation failed"
+	}
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// APIError is returned when the API responds with an error status that has
+// no more specific error type. Message is the error message from the
+// response body, when it has one. RequestID is the X-Request-ID of the
+// request, see WithRequestIDGenerator.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RequestID  string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("unexpected status: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request ID " + e.RequestID + ")"
+	}
+	return msg
+}
@@ -0,0 +1,162 @@
+// Package feature checks the feature flags of the Superclouds API, so that
+// operations that are not available on an organization's plan or API version
+// fail fast on the client instead of with an API error.
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a Registry caches a flag by default.
+const DefaultTTL = 5 * time.Minute
+
+// Features that gate SDK operations, see WithFeatureGating.
+const (
+	FeatureUserMerge        = "user_merge"
+	FeatureTrustScore       = "trust_score"
+	FeatureUserQuota        = "user_quota"
+	FeatureCustomAttributes = "custom_attributes"
+	FeatureUserSchema       = "user_schema"
+)
+
+// operationFeatures maps the operations named with superclouds.WithOperation,
+// as "resource.method", to the feature they require.
+var operationFeatures = map[string]string{
+	"users.merge":             FeatureUserMerge,
+	"users.get_trust_score":   FeatureTrustScore,
+	"users.get_quota":         FeatureUserQuota,
+	"users.update_attributes": FeatureCustomAttributes,
+	"user_schema.get":         FeatureUserSchema,
+	"user_schema.update":      FeatureUserSchema,
+}
+
+// ErrFeatureDisabled is returned by operations whose feature is disabled, see WithFeatureGating.
+var ErrFeatureDisabled = errors.New("feature disabled")
+
+// Option configures a Registry created by NewRegistry.
+type Option func(*Registry)
+
+// WithTTL sets how long flags are cached; it defaults to DefaultTTL.
+func WithTTL(ttl time.Duration) Option {
+	return func(r *Registry) {
+		r.ttl = ttl
+	}
+}
+
+// Registry reads feature flags from the API and caches them.
+type Registry struct {
+	config *superclouds.Config
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	flags map[string]flag
+}
+
+// flag is a cached feature flag.
+type flag struct {
+	enabled bool
+	expires time.Time
+}
+
+// NewRegistry creates a Registry that reads flags with cfg. When cfg is nil,
+// the Registry uses the Config that WithFeatureGating is applied to.
+//
+// Example usage:
+//
+//	registry := feature.NewRegistry(cfg, feature.WithTTL(time.Minute))
+//	if registry.IsEnabled(ctx, feature.FeatureTrustScore) {
+//	    score, err := usersClient.GetUserTrustScore(ctx, userID)
+//	}
+func NewRegistry(cfg *superclouds.Config, opts ...Option) *Registry {
+	r := &Registry{config: cfg, ttl: DefaultTTL, flags: make(map[string]flag)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// IsEnabled reports whether feature is enabled, calling GET /features/{name}
+// unless the flag is cached. When the flag cannot be read, the error is
+// logged and the feature is reported enabled, so that an unavailable flag
+// does not block calls that the API would accept; such results are not cached.
+func (r *Registry) IsEnabled(ctx context.Context, feature string) bool {
+	r.mu.Lock()
+	f, ok := r.flags[feature]
+	r.mu.Unlock()
+	if ok && time.Now().Before(f.expires) {
+		return f.enabled
+	}
+
+	enabled, err := r.fetch(ctx, feature)
+	if err != nil {
+		r.config.Logger().Error("failed to read feature flag", "feature", feature, "error", err)
+		return true
+	}
+
+	r.mu.Lock()
+	r.flags[feature] = flag{enabled: enabled, expires: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+	return enabled
+}
+
+// fetch reads the flag of feature from the API.
+func (r *Registry) fetch(ctx context.Context, feature string) (bool, error) {
+	if r.config == nil {
+		return false, fmt.Errorf("registry has no config")
+	}
+	ctx = superclouds.WithOperation(ctx, "features", "get")
+
+	apiResponse, err := generic.Get[generic.Response[struct {
+		Enabled bool `json:"enabled"`
+	}]](ctx, r.config, "/features/"+url.PathEscape(feature), nil)
+	if err != nil {
+		return false, err
+	}
+	return apiResponse.Data.Enabled, nil
+}
+
+// WithFeatureGating makes the operations that require a feature, such as
+// MergeUsers and GetUserTrustScore, check its flag in reg before sending
+// their request. Operations whose feature is disabled fail with an error
+// matching ErrFeatureDisabled.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    feature.WithFeatureGating(feature.NewRegistry(nil)),
+//	)
+func WithFeatureGating(reg *Registry) superclouds.Option {
+	return func(c *superclouds.Config) {
+		if reg.config == nil {
+			reg.config = c
+		}
+		superclouds.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return &gatingRoundTripper{next: next, registry: reg}
+		})(c)
+	}
+}
+
+// gatingRoundTripper fails the requests of operations whose feature is disabled.
+type gatingRoundTripper struct {
+	next     http.RoundTripper
+	registry *Registry
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *gatingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if resource, method, ok := superclouds.OperationFrom(req.Context()); ok {
+		if feature := operationFeatures[resource+"."+method]; feature != "" && !t.registry.IsEnabled(req.Context(), feature) {
+			return nil, fmt.Errorf("%w: %s", ErrFeatureDisabled, feature)
+		}
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,60 @@
+// Package fieldmask restricts SDK values to a chosen set of fields on the client side,
+// for endpoints that do not support field selection natively.
+package fieldmask
+
+import (
+	"encoding/json"
+)
+
+// Parse returns a copy of input that only contains the given fields.
+// Fields are matched against the JSON names of input, so Parse works on any
+// SDK type. A struct or map yields a map[string]interface{}, and a slice
+// yields a []interface{} of masked elements. Values that cannot be represented
+// as JSON objects, and inputs given no fields, are returned unchanged.
+//
+// Example usage:
+//
+//	masked := fieldmask.Parse(usersOutput.Users, []string{"id", "email"})
+//	log.Printf("Users: %v", masked)
+func Parse(input interface{}, fields []string) interface{} {
+	if len(fields) == 0 {
+		return input
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return input
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return input
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return mask(v, keep)
+	case []interface{}:
+		for i, elem := range v {
+			if obj, ok := elem.(map[string]interface{}); ok {
+				v[i] = mask(obj, keep)
+			}
+		}
+		return v
+	}
+	return input
+}
+
+func mask(obj map[string]interface{}, keep map[string]bool) map[string]interface{} {
+	for key := range obj {
+		if !keep[key] {
+			delete(obj, key)
+		}
+	}
+	return obj
+}
@@ -0,0 +1,147 @@
+// Package generic implements the request/response cycle shared by the SDK clients:
+// marshal the input, send the request, check the status and decode the output.
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Response represents the structure of the response from the Superclouds API,
+// with the data field decoded as T.
+type Response[T any] struct {
+	Data    T        `json:"data"`
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors"`
+	Page    int      `json:"page"`
+	Pages   int      `json:"pages"`
+	Size    int      `json:"size"`
+	Total   int      `json:"total"`
+	// NextCursor is set by cursor-paginated endpoints and is empty on the last page.
+	NextCursor string `json:"next_cursor"`
+}
+
+// HasMore reports whether pages follow this one in a page-number paginated response.
+func (r *Response[T]) HasMore() bool {
+	return r.Page < r.Pages
+}
+
+// RequestOption customises a request before it is sent.
+type RequestOption func(*http.Request)
+
+// WithHeader sets a header on the request.
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+type responseHeaderKey struct{}
+
+// WithResponseHeader returns a copy of ctx that makes a request sent with it
+// store the header of its successful response in *dst, for the values that
+// are not part of the body, such as the ETag.
+//
+// Example usage:
+//
+//	var header http.Header
+//	output, err := generic.Get[generic.Response[users.UserOutput]](generic.WithResponseHeader(ctx, &header), cfg, "/user", nil)
+//	etag := header.Get("ETag")
+func WithResponseHeader(ctx context.Context, dst *http.Header) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, dst)
+}
+
+// Get sends a GET request to path with the given query parameters and decodes the response body into O.
+//
+// Example usage:
+//
+//	output, err := generic.Get[generic.Response[[]users.User]](ctx, cfg, "/users", url.Values{"size": {"10"}})
+func Get[O any](ctx context.Context, cfg *superclouds.Config, path string, params url.Values, opts ...RequestOption) (*O, error) {
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return do[O](ctx, cfg, http.MethodGet, path, nil, opts)
+}
+
+// Post marshals input as JSON, sends it in a POST request to path and decodes the response body into O.
+// A nil input sends the request without a body.
+func Post[I, O any](ctx context.Context, cfg *superclouds.Config, path string, input *I, opts ...RequestOption) (*O, error) {
+	reqBody, err := marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	return do[O](ctx, cfg, http.MethodPost, path, reqBody, opts)
+}
+
+// Patch marshals input as JSON, sends it in a PATCH request to path and decodes the response body into O.
+// A nil input sends the request without a body.
+func Patch[I, O any](ctx context.Context, cfg *superclouds.Config, path string, input *I, opts ...RequestOption) (*O, error) {
+	reqBody, err := marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	return do[O](ctx, cfg, http.MethodPatch, path, reqBody, opts)
+}
+
+// Delete sends a DELETE request to path and discards the response body.
+func Delete(ctx context.Context, cfg *superclouds.Config, path string, opts ...RequestOption) error {
+	_, err := do[struct{}](ctx, cfg, http.MethodDelete, path, nil, opts)
+	return err
+}
+
+// marshal encodes input as JSON, returning a nil body for a nil input.
+func marshal[I any](input *I) ([]byte, error) {
+	if input == nil {
+		return nil, nil
+	}
+	reqBody, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling JSON: %w", err)
+	}
+	return reqBody, nil
+}
+
+// do executes a request against path and decodes the response body into O.
+// A non-2xx status is returned as an error, and an empty body yields the zero O.
+func do[O any](ctx context.Context, cfg *superclouds.Config, method, path string, body []byte, opts []RequestOption) (*O, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := cfg.NewRequest(ctx, method, cfg.URL(path), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := cfg.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	if dst, ok := ctx.Value(responseHeaderKey{}).(*http.Header); ok && dst != nil {
+		*dst = resp.Header
+	}
+
+	var output O
+	if err := cfg.DecodeResponse(resp.Body, &output); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return &output, nil
+}
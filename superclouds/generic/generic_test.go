@@ -0,0 +1,215 @@
+package generic_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type item struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// newTestConfig returns a Config that sends its requests to a test server
+// serving handler.
+func newTestConfig(t *testing.T, handler http.HandlerFunc) *superclouds.Config {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	cfg, err := superclouds.NewConfigWithOptions("", "",
+		superclouds.WithBaseURL(srv.URL),
+		superclouds.WithToken("test-token"),
+		superclouds.WithHTTPClient(srv.Client()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	return cfg
+}
+
+func TestGet(t *testing.T) {
+	var method, path string
+	var query url.Values
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.Query()
+		w.Write([]byte(`{"data":[{"id":"1","name":"one"}],"page":1,"pages":2}`))
+	})
+
+	output, err := generic.Get[generic.Response[[]item]](context.Background(), cfg, "/items", url.Values{"size": {"10"}})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if method != http.MethodGet || path != "/items" || query.Get("size") != "10" {
+		t.Errorf("request = %s %s?%s, want GET /items?size=10", method, path, query.Encode())
+	}
+	if len(output.Data) != 1 || output.Data[0] != (item{ID: "1", Name: "one"}) {
+		t.Errorf("Data = %+v, want the decoded item", output.Data)
+	}
+}
+
+func TestPostAndPatchSendJSONBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		send   func(cfg *superclouds.Config, input *item) (*generic.Response[item], error)
+	}{
+		{"Post", http.MethodPost, func(cfg *superclouds.Config, input *item) (*generic.Response[item], error) {
+			return generic.Post[item, generic.Response[item]](context.Background(), cfg, "/items", input)
+		}},
+		{"Patch", http.MethodPatch, func(cfg *superclouds.Config, input *item) (*generic.Response[item], error) {
+			return generic.Patch[item, generic.Response[item]](context.Background(), cfg, "/items", input)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method string
+			var received item
+			cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("failed to decode request body: %v", err)
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": item{ID: "1", Name: received.Name}})
+			})
+
+			output, err := tt.send(cfg, &item{Name: "one"})
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if method != tt.method {
+				t.Errorf("method = %s, want %s", method, tt.method)
+			}
+			if received.Name != "one" {
+				t.Errorf("request body name = %q, want %q", received.Name, "one")
+			}
+			if output.Data != (item{ID: "1", Name: "one"}) {
+				t.Errorf("Data = %+v, want the decoded item", output.Data)
+			}
+		})
+	}
+}
+
+func TestPostNilInputSendsNoBody(t *testing.T) {
+	var body []byte
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	})
+
+	if _, err := generic.Post[struct{}, struct{}](context.Background(), cfg, "/items/1/approve", nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if len(body) != 0 {
+		t.Errorf("request body = %q, want empty", body)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var method, path string
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := generic.Delete(context.Background(), cfg, "/items/1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if method != http.MethodDelete || path != "/items/1" {
+		t.Errorf("request = %s %s, want DELETE /items/1", method, path)
+	}
+}
+
+func TestEmptyBodyYieldsZeroValue(t *testing.T) {
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	output, err := generic.Get[generic.Response[item]](context.Background(), cfg, "/items/1", nil)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if output == nil || output.Data != (item{}) {
+		t.Errorf("output = %+v, want the zero value", output)
+	}
+}
+
+func TestNon2xxStatusReturnsError(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(err error) bool
+	}{
+		{http.StatusNotFound, func(err error) bool {
+			var notFoundErr superclouds.NotFoundError
+			return errors.As(err, &notFoundErr)
+		}},
+		{http.StatusConflict, func(err error) bool {
+			var conflictErr superclouds.ConflictError
+			return errors.As(err, &conflictErr)
+		}},
+		{http.StatusPreconditionFailed, func(err error) bool {
+			return errors.Is(err, superclouds.ErrPreconditionFailed)
+		}},
+		{http.StatusInternalServerError, func(err error) bool {
+			var apiErr *superclouds.APIError
+			return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusInternalServerError && apiErr.Message == "boom"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"message":"boom"}`))
+			})
+
+			output, err := generic.Get[generic.Response[item]](context.Background(), cfg, "/items/1", nil)
+			if output != nil || !tt.check(err) {
+				t.Errorf("Get = %+v, %v; want a nil output and the matching error", output, err)
+			}
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	var requestHeader string
+	cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		requestHeader = r.Header.Get("If-Match")
+		w.Header().Set("ETag", `"v2"`)
+		w.Write([]byte(`{"data":{}}`))
+	})
+
+	var header http.Header
+	ctx := generic.WithResponseHeader(context.Background(), &header)
+	if _, err := generic.Get[generic.Response[item]](ctx, cfg, "/items/1", nil, generic.WithHeader("If-Match", `"v1"`)); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if requestHeader != `"v1"` {
+		t.Errorf("If-Match = %q, want %q", requestHeader, `"v1"`)
+	}
+	if got := header.Get("ETag"); got != `"v2"` {
+		t.Errorf("ETag = %q, want %q", got, `"v2"`)
+	}
+}
+
+func TestResponseHasMore(t *testing.T) {
+	tests := []struct {
+		page, pages int
+		want        bool
+	}{
+		{1, 2, true},
+		{2, 2, false},
+		{0, 0, false},
+	}
+	for _, tt := range tests {
+		r := generic.Response[item]{Page: tt.page, Pages: tt.pages}
+		if got := r.HasMore(); got != tt.want {
+			t.Errorf("HasMore() with page %d of %d = %v, want %v", tt.page, tt.pages, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package gob registers the SDK's types with encoding/gob, so that they can
+// be sent as interface values, such as error values, over gob-based RPC or
+// stored in gob-encoded caches.
+package gob
+
+import (
+	stdgob "encoding/gob"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/approval"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/directory"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/loginpolicy"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/mfa"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/notification/email"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/organizations"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/passwordpolicy"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/schema/registry"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/selfservice"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"sync"
+)
+
+var registerOnce sync.Once
+
+// Register registers the errors of the SDK and the input and output types of
+// its API clients with encoding/gob. Types only need registering to be sent
+// as interface values; concrete values can be encoded without it. Register
+// may be called more than once.
+//
+// Example usage:
+//
+//	gob.Register()
+//	var err error = &superclouds.APIError{StatusCode: 500}
+//	if encodeErr := stdgob.NewEncoder(w).Encode(&err); encodeErr != nil {
+//	    log.Fatalf("Failed to encode error: %v", encodeErr)
+//	}
+func Register() {
+	registerOnce.Do(func() {
+		for _, v := range types {
+			stdgob.Register(v)
+		}
+	})
+}
+
+// types lists a value of each registered type. Errors are registered in the
+// form, value or pointer, in which the SDK returns them.
+var types = []interface{}{
+	// superclouds
+	superclouds.NotFoundError{},
+	superclouds.ConflictError{},
+	superclouds.ValidationError{},
+	superclouds.FieldError{},
+	&superclouds.APIError{},
+	&superclouds.RateLimitError{},
+
+	// users
+	users.User{},
+	users.UserOutput{},
+	users.ListUsersInput{},
+	users.ListUsersOutput{},
+	users.CreateUserInput{},
+	users.UpdateUserInput{},
+	users.DeleteUserInput{},
+	users.UpdateUserRoleInput{},
+	users.ChangePasswordInput{},
+	users.TrustScore{},
+	users.RiskFactor{},
+	users.UserQuota{},
+	users.ResourceQuota{},
+
+	// approval
+	approval.Approval{},
+	approval.ApprovalRequest{},
+
+	// directory
+	directory.DirectorySyncConfig{},
+	directory.SyncJob{},
+	directory.UpdateDirSyncInput{},
+
+	// loginpolicy
+	loginpolicy.LoginPolicy{},
+	loginpolicy.UpdateLoginPolicyInput{},
+
+	// organizations
+	organizations.Organization{},
+	organizations.UpdateOrganizationInput{},
+	organizations.Member{},
+	organizations.ListMembersInput{},
+	organizations.ListMembersOutput{},
+	organizations.InviteUserInput{},
+	organizations.InvitationOutput{},
+	organizations.ListInvitationsInput{},
+	organizations.ListInvitationsOutput{},
+
+	// mfa
+	mfa.MFAStatus{},
+	mfa.ChallengeOutput{},
+	mfa.VerifyOutput{},
+
+	// passwordpolicy
+	passwordpolicy.PasswordPolicy{},
+	passwordpolicy.UpdatePasswordPolicyInput{},
+
+	// selfservice
+	selfservice.APIKey{},
+	selfservice.Session{},
+
+	// email
+	email.SendEmailInput{},
+	email.Attachment{},
+
+	// registry
+	registry.UserSchema{},
+	registry.AttributeDefinition{},
+	registry.UpdateUserSchemaInput{},
+}
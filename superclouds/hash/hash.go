@@ -0,0 +1,102 @@
+// Package hash computes stable hashes of users, so that watchers that poll
+// the API can tell whether anything changed without comparing every field.
+package hash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+)
+
+// UserHash returns the SHA-256 hash of the canonical JSON encoding of u, in hexadecimal.
+// The canonical encoding sorts object keys, so the hash only changes when a field of u does.
+//
+// Example usage:
+//
+//	h, err := hash.UserHash(&user)
+//	if err != nil {
+//	    log.Fatalf("Failed to hash user: %v", err)
+//	}
+//	if h != lastHash {
+//	    log.Printf("User %s changed", user.Id)
+//	}
+func UserHash(u *users.User) (string, error) {
+	sum, err := userSum(u)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// UsersHash returns the root of a Merkle tree over the hashes of list, in
+// hexadecimal. Each level hashes pairs of the level below, the last hash of
+// an odd level being paired with itself. The root depends on the order of
+// list; the hash of an empty list is that of no data.
+//
+// Example usage:
+//
+//	h, err := hash.UsersHash(page)
+//	if err != nil {
+//	    log.Fatalf("Failed to hash users: %v", err)
+//	}
+func UsersHash(list []*users.User) (string, error) {
+	if len(list) == 0 {
+		sum := sha256.Sum256(nil)
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	level := make([][sha256.Size]byte, len(list))
+	for i, u := range list {
+		sum, err := userSum(u)
+		if err != nil {
+			return "", fmt.Errorf("user %d: %w", i, err)
+		}
+		level[i] = sum
+	}
+
+	for len(level) > 1 {
+		next := make([][sha256.Size]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(next, sha256.Sum256(append(level[i][:], right[:]...)))
+		}
+		level = next
+	}
+	return hex.EncodeToString(level[0][:]), nil
+}
+
+// userSum returns the SHA-256 hash of the canonical JSON encoding of u.
+func userSum(u *users.User) ([sha256.Size]byte, error) {
+	if u == nil {
+		return [sha256.Size]byte{}, fmt.Errorf("missing user")
+	}
+	data, err := canonicalJSON(u)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("failed to encode user: %w", err)
+	}
+	return sha256.Sum256(data), nil
+}
+
+// canonicalJSON encodes v as JSON with the keys of every object sorted.
+// Decoding into generic values and encoding again sorts the keys, as
+// encoding/json writes maps in key order; numbers are kept verbatim.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
@@ -0,0 +1,48 @@
+package superclouds
+
+import (
+	"net/http"
+)
+
+// WithStaticHeaders adds headers to every request, such as the routing
+// headers required by an API gateway. Calling it several times merges the
+// headers, later values replacing earlier ones for the same name. Headers
+// already set on a request, by the SDK or by the caller, are kept.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithStaticHeaders(map[string]string{
+//	        "X-Gateway-Key": gatewayKey,
+//	        "X-Environment": "staging",
+//	    }),
+//	)
+func WithStaticHeaders(headers map[string]string) Option {
+	return func(c *Config) {
+		if c.staticHeaders == nil {
+			c.staticHeaders = make(http.Header, len(headers))
+		}
+		for name, value := range headers {
+			c.staticHeaders.Set(name, value)
+		}
+	}
+}
+
+// staticHeadersRoundTripper wraps a RoundTripper and adds the static headers to each request.
+type staticHeadersRoundTripper struct {
+	next    http.RoundTripper
+	headers http.Header
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *staticHeadersRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		if _, ok := req.Header[name]; !ok {
+			req.Header[name] = values
+		}
+	}
+	return t.next.RoundTrip(req)
+}
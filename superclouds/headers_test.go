@@ -0,0 +1,33 @@
+package superclouds_test
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStaticHeaders(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv,
+		superclouds.WithStaticHeaders(map[string]string{"X-Gateway-Key": "gateway-key"}),
+		superclouds.WithStaticHeaders(map[string]string{"X-Environment": "staging"}),
+	)
+
+	if _, err := get(t, cfg, "/users/me"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if got := header.Get("X-Gateway-Key"); got != "gateway-key" {
+		t.Errorf("X-Gateway-Key = %q, want %q", got, "gateway-key")
+	}
+	if got := header.Get("X-Environment"); got != "staging" {
+		t.Errorf("X-Environment = %q, want %q", got, "staging")
+	}
+	if header.Get("Authorization") != "Bearer test-token" || header.Get("X-Request-ID") == "" {
+		t.Errorf("static headers replaced the headers of the other options: %v", header)
+	}
+}
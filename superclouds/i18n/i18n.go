@@ -0,0 +1,173 @@
+// Package i18n translates the SDK's API errors into the caller's language.
+//
+// English ("en") and Spanish ("es") are included. Translations only cover
+// the typed errors of API error responses, such as superclouds.NotFoundError
+// and superclouds.APIError; other errors keep their message.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the locale used for unsupported locales.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// ErrorTranslator translates errors into the supported locales.
+type ErrorTranslator struct {
+	messages map[string]map[string]string
+}
+
+// NewErrorTranslator creates an ErrorTranslator with the included translations.
+//
+// Example usage:
+//
+//	translator, err := i18n.NewErrorTranslator()
+//	if err != nil {
+//	    log.Fatalf("Failed to load translations: %v", err)
+//	}
+//	log.Println(translator.Translate(err, "es"))
+func NewErrorTranslator() (*ErrorTranslator, error) {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translations: %w", err)
+	}
+
+	t := &ErrorTranslator{messages: make(map[string]map[string]string, len(entries))}
+	for _, entry := range entries {
+		data, err := localeFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read translations: %w", err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse translations %s: %w", entry.Name(), err)
+		}
+		t.messages[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	return t, nil
+}
+
+// Translate returns the message of err in locale. A regional locale such as
+// "es-MX" uses the translations of its language, and unsupported locales
+// use DefaultLocale. Errors without a translation return err.Error().
+func (t *ErrorTranslator) Translate(err error, locale string) string {
+	messages := t.locale(locale)
+
+	var notFoundErr superclouds.NotFoundError
+	var conflictErr superclouds.ConflictError
+	var validationErr superclouds.ValidationError
+	var apiErr *superclouds.APIError
+	switch {
+	case errors.Is(err, superclouds.ErrPreconditionFailed):
+		return messages["precondition_failed"]
+	case errors.As(err, &notFoundErr):
+		key := "not_found"
+		if notFoundErr.Identifier == "" {
+			key = "not_found_unnamed"
+		}
+		return format(messages[key], "resource", notFoundErr.ResourceType, "identifier", strconv.Quote(notFoundErr.Identifier))
+	case errors.As(err, &conflictErr):
+		switch {
+		case conflictErr.Message == "":
+			return messages["conflict_default"]
+		case conflictErr.ExistingID != "":
+			return format(messages["conflict_existing"], "message", conflictErr.Message, "existing_id", conflictErr.ExistingID)
+		}
+		return format(messages["conflict"], "message", conflictErr.Message)
+	case errors.As(err, &validationErr):
+		if len(validationErr.Fields) == 0 {
+			return messages["validation_default"]
+		}
+		fields := make([]string, len(validationErr.Fields))
+		for i, f := range validationErr.Fields {
+			fields[i] = f.Field + ": " + f.Message
+		}
+		return format(messages["validation"], "fields", strings.Join(fields, "; "))
+	case errors.As(err, &apiErr):
+		status := strconv.Itoa(apiErr.StatusCode)
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized:
+			return messages["unauthorized"]
+		case apiErr.StatusCode == http.StatusForbidden:
+			return messages["forbidden"]
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			return messages["rate_limited"]
+		case apiErr.StatusCode >= 500:
+			return format(messages["server_error"], "status", status)
+		case apiErr.Message == "":
+			return format(messages["api_error_default"], "status", status)
+		}
+		return format(messages["api_error"], "status", status, "message", apiErr.Message)
+	}
+	return err.Error()
+}
+
+// locale returns the messages of locale, falling back to its language and then to DefaultLocale.
+func (t *ErrorTranslator) locale(locale string) map[string]string {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if messages, ok := t.messages[locale]; ok {
+		return messages
+	}
+	if language, _, ok := strings.Cut(locale, "-"); ok {
+		if messages, ok := t.messages[language]; ok {
+			return messages
+		}
+	}
+	return t.messages[DefaultLocale]
+}
+
+// format replaces the {name} placeholders of msg with the given name and value pairs.
+func format(msg string, pairs ...string) string {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		msg = strings.ReplaceAll(msg, "{"+pairs[i]+"}", pairs[i+1])
+	}
+	return msg
+}
+
+// TranslatedError is an error whose message has been translated.
+// It wraps the original error, so errors.As and errors.Is still see it.
+type TranslatedError struct {
+	Err     error
+	Message string
+}
+
+// Error implements error.
+func (e *TranslatedError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the original error.
+func (e *TranslatedError) Unwrap() error {
+	return e.Err
+}
+
+// WithLocale translates the errors of API error responses into locale before
+// the SDK returns them, see superclouds.WithErrorTranslator.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    i18n.WithLocale("es"),
+//	)
+func WithLocale(locale string) superclouds.Option {
+	translator, err := NewErrorTranslator()
+	if err != nil {
+		// The translations are embedded, so this only happens if they are malformed.
+		panic(err)
+	}
+	return superclouds.WithErrorTranslator(func(err error) error {
+		return &TranslatedError{Err: err, Message: translator.Translate(err, locale)}
+	})
+}
@@ -0,0 +1,205 @@
+// Package jsonschema validates API responses against JSON Schemas of the
+// types they are decoded into, so that unexpected changes to the API are
+// reported instead of silently decoded into zero values.
+//
+// Schemas are embedded for the responses of the users endpoints. Responses
+// decoded into other types are decoded without validation.
+package jsonschema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// schemaURL is the $id of the embedded schema document.
+const schemaURL = "https://api.superclouds.ooo/schemas/users.json"
+
+//go:embed schemas/users.json
+var schemaFS embed.FS
+
+// definitions maps each validated type to its definition in the schema document.
+var definitions = map[reflect.Type]string{
+	reflect.TypeOf(generic.Response[users.UserOutput]{}): "userResponse",
+	reflect.TypeOf(generic.Response[[]users.User]{}):     "usersResponse",
+	reflect.TypeOf(generic.Response[users.TrustScore]{}): "trustScoreResponse",
+	reflect.TypeOf(generic.Response[users.UserQuota]{}):  "userQuotaResponse",
+}
+
+var (
+	compileOnce sync.Once
+	schemas     map[reflect.Type]*jsonschema.Schema
+)
+
+// schemaFor returns the schema of the type v points to, or nil if it has none.
+func schemaFor(v interface{}) *jsonschema.Schema {
+	compileOnce.Do(compileSchemas)
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return schemas[t]
+}
+
+// compileSchemas compiles the definitions of the embedded schema document.
+func compileSchemas() {
+	data, err := schemaFS.ReadFile("schemas/users.json")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read JSON schema: %v", err))
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		panic(fmt.Sprintf("invalid JSON schema: %v", err))
+	}
+
+	c := jsonschema.NewCompiler()
+	// Timestamps that time.Time cannot parse are reported as violations too.
+	c.AssertFormat()
+	if err := c.AddResource(schemaURL, doc); err != nil {
+		panic(fmt.Sprintf("invalid JSON schema: %v", err))
+	}
+	schemas = make(map[reflect.Type]*jsonschema.Schema, len(definitions))
+	for t, def := range definitions {
+		sch, err := c.Compile(schemaURL + "#/$defs/" + def)
+		if err != nil {
+			panic(fmt.Sprintf("invalid JSON schema %s: %v", def, err))
+		}
+		schemas[t] = sch
+	}
+}
+
+// Violation is a part of a response that does not match its schema.
+// Path is the JSON pointer of the offending value, such as /data/email.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+// SchemaError is returned when a response does not match the schema of the
+// type it is decoded into. It lists every violation found.
+type SchemaError struct {
+	Type       string
+	Violations []Violation
+}
+
+// Error implements error.
+func (e *SchemaError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		path := v.Path
+		if path == "" {
+			path = "/"
+		}
+		msgs[i] = path + ": " + v.Message
+	}
+	return fmt.Sprintf("response does not match the schema of %s: %s", e.Type, strings.Join(msgs, "; "))
+}
+
+// ValidatingDecoder reads JSON values from an input stream like a
+// json.Decoder, and validates each value against the schema of the type it
+// is decoded into before decoding it.
+type ValidatingDecoder struct {
+	dec *json.Decoder
+}
+
+// NewValidatingDecoder returns a ValidatingDecoder that reads from r.
+//
+// Example usage:
+//
+//	var output generic.Response[users.UserOutput]
+//	if err := jsonschema.NewValidatingDecoder(resp.Body).Decode(&output); err != nil {
+//	    log.Fatalf("Failed to decode response: %v", err)
+//	}
+func NewValidatingDecoder(r io.Reader) *ValidatingDecoder {
+	return &ValidatingDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next JSON value and stores it in v. When the type of v
+// has a schema and the value does not match it, v is left unchanged and a
+// *SchemaError is returned. At the end of the input, Decode returns io.EOF.
+func (d *ValidatingDecoder) Decode(v interface{}) error {
+	var raw json.RawMessage
+	if err := d.dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	if sch := schemaFor(v); sch != nil {
+		inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+		if err != nil {
+			return err
+		}
+		if err := sch.Validate(inst); err != nil {
+			var validationErr *jsonschema.ValidationError
+			if !errors.As(err, &validationErr) {
+				return err
+			}
+			return newSchemaError(reflect.TypeOf(v), validationErr)
+		}
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+var (
+	// printer formats the messages of violations.
+	printer = message.NewPrinter(language.English)
+	// pointerEscaper escapes ~ and / in JSON pointer tokens.
+	pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+)
+
+// newSchemaError lists the violations of a validation error of a value decoded into t.
+func newSchemaError(t reflect.Type, err *jsonschema.ValidationError) *SchemaError {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	schemaErr := &SchemaError{Type: t.String()}
+	addViolations(schemaErr, err)
+	return schemaErr
+}
+
+// addViolations adds the leaves of the tree of err to e. Inner errors, such
+// as those of references, only report that the errors below them failed.
+func addViolations(e *SchemaError, err *jsonschema.ValidationError) {
+	if len(err.Causes) == 0 {
+		var path strings.Builder
+		for _, token := range err.InstanceLocation {
+			path.WriteString("/" + pointerEscaper.Replace(token))
+		}
+		e.Violations = append(e.Violations, Violation{Path: path.String(), Message: err.ErrorKind.LocalizedString(printer)})
+		return
+	}
+	for _, cause := range err.Causes {
+		addViolations(e, cause)
+	}
+}
+
+// WithResponseValidation makes the SDK validate the responses it decodes
+// with a ValidatingDecoder when enabled is true. Invalid responses fail with
+// a *SchemaError. Passing false restores the default decoding.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    jsonschema.WithResponseValidation(true),
+//	)
+func WithResponseValidation(enabled bool) superclouds.Option {
+	if !enabled {
+		return superclouds.WithResponseDecoder(nil)
+	}
+	return superclouds.WithResponseDecoder(func(r io.Reader, v interface{}) error {
+		return NewValidatingDecoder(r).Decode(v)
+	})
+}
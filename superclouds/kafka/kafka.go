@@ -0,0 +1,99 @@
+// Package kafka publishes user change events to Apache Kafka.
+//
+// Events are avro.UserChangeEvent records in Avro binary format, keyed by the
+// email of the user so that the events of a user stay in order.
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/avro"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"time"
+)
+
+// eventTypeHeader carries the change type of an event, so that consumers can filter without decoding it.
+const eventTypeHeader = "event-type"
+
+// MessageWriter writes messages to Kafka. It is implemented by *kafka.Writer.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// EventPublisher publishes user change events to a Kafka topic.
+// It implements users.EventPublisher.
+type EventPublisher struct {
+	writer   MessageWriter
+	schemaID int
+}
+
+var _ users.EventPublisher = (*EventPublisher)(nil)
+
+// NewEventPublisher creates an EventPublisher that writes events with writer.
+// When schemaID is not zero, each message starts with the schema ID header of
+// the schema registry wire format, see avro.WithSchemaID.
+//
+// Example usage:
+//
+//	publisher := kafka.NewEventPublisher(&kafkago.Writer{
+//	    Addr:  kafkago.TCP("localhost:9092"),
+//	    Topic: "user-events",
+//	}, 0)
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    users.WithEventPublisher(publisher),
+//	)
+func NewEventPublisher(writer MessageWriter, schemaID int) *EventPublisher {
+	return &EventPublisher{writer: writer, schemaID: schemaID}
+}
+
+// PublishUserCreated publishes an avro.ChangeCreated event for user.
+func (p *EventPublisher) PublishUserCreated(ctx context.Context, user *users.UserOutput) error {
+	return p.publish(ctx, &avro.UserChangeEvent{
+		Type:  avro.ChangeCreated,
+		Email: user.Email,
+		User:  &user.User,
+	})
+}
+
+// PublishUserDeleted publishes an avro.ChangeDeleted event for the user with the given email.
+func (p *EventPublisher) PublishUserDeleted(ctx context.Context, email string) error {
+	return p.publish(ctx, &avro.UserChangeEvent{
+		Type:  avro.ChangeDeleted,
+		Email: email,
+	})
+}
+
+// PublishRoleChanged publishes an avro.ChangeRoleChanged event for the user with the given email.
+func (p *EventPublisher) PublishRoleChanged(ctx context.Context, email, oldRole, newRole string) error {
+	return p.publish(ctx, &avro.UserChangeEvent{
+		Type:    avro.ChangeRoleChanged,
+		Email:   email,
+		OldRole: oldRole,
+		NewRole: newRole,
+	})
+}
+
+// publish stamps event with the current time and writes it to Kafka.
+func (p *EventPublisher) publish(ctx context.Context, event *avro.UserChangeEvent) error {
+	event.OccurredAt = time.Now()
+	value, err := avro.MarshalUserChangeEvent(event)
+	if err != nil {
+		return err
+	}
+	if p.schemaID != 0 {
+		value = avro.WithSchemaID(p.schemaID, value)
+	}
+
+	err = p.writer.WriteMessages(ctx, kafka.Message{
+		Key:     []byte(event.Email),
+		Value:   value,
+		Headers: []kafka.Header{{Key: eventTypeHeader, Value: []byte(event.Type)}},
+		Time:    event.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
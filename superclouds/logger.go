@@ -0,0 +1,48 @@
+package superclouds
+
+// Logger receives the SDK's internal log messages, such as requests,
+// retries and token refreshes. kvs are alternating keys and values.
+// The SDK never logs tokens, request bodies or query strings.
+type Logger interface {
+	Info(msg string, kvs ...interface{})
+	Debug(msg string, kvs ...interface{})
+	Warn(msg string, kvs ...interface{})
+	Error(msg string, kvs ...interface{})
+}
+
+// NopLogger is a Logger that discards all messages. It is the default Logger of a Config.
+type NopLogger struct{}
+
+// Info implements Logger.
+func (NopLogger) Info(string, ...interface{}) {}
+
+// Debug implements Logger.
+func (NopLogger) Debug(string, ...interface{}) {}
+
+// Warn implements Logger.
+func (NopLogger) Warn(string, ...interface{}) {}
+
+// Error implements Logger.
+func (NopLogger) Error(string, ...interface{}) {}
+
+// WithLogger sets the Logger that receives the SDK's log messages.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithLogger(myLogger),
+//	)
+func WithLogger(logger Logger) Option {
+	return func(c *Config) {
+		c.logger = logger
+	}
+}
+
+// Logger returns the Logger of the Config, or a NopLogger if none is set.
+func (c *Config) Logger() Logger {
+	if c.logger == nil {
+		return NopLogger{}
+	}
+	return c.logger
+}
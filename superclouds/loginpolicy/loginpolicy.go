@@ -0,0 +1,114 @@
+package loginpolicy
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+)
+
+// Client provides methods to manage the organisation login policy through the Superclouds API.
+type Client struct {
+	config *superclouds.Config
+}
+
+// NewClient creates a new login policy Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	policyClient := loginpolicy.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg}
+}
+
+// Login methods accepted in the AllowedMethods field of LoginPolicy.
+const (
+	MethodPassword = "password"
+	MethodSSO      = "sso"
+	MethodOTP      = "otp"
+)
+
+// LoginPolicy describes the login-time security controls of an organisation.
+type LoginPolicy struct {
+	AllowedMethods         []string `json:"allowed_methods"`
+	MaxFailedAttempts      int      `json:"max_failed_attempts"`
+	LockoutDurationMinutes int      `json:"lockout_duration_minutes"`
+	RequireMFAForAdmins    bool     `json:"require_mfa_for_admins"`
+	SessionTimeoutMinutes  int      `json:"session_timeout_minutes"`
+}
+
+// UpdateLoginPolicyInput defines the input parameters for the UpdatePolicy method.
+// Fields left nil are not changed.
+type UpdateLoginPolicyInput struct {
+	AllowedMethods         []string `json:"allowed_methods,omitempty"`
+	MaxFailedAttempts      *int     `json:"max_failed_attempts,omitempty"`
+	LockoutDurationMinutes *int     `json:"lockout_duration_minutes,omitempty"`
+	RequireMFAForAdmins    *bool    `json:"require_mfa_for_admins,omitempty"`
+	SessionTimeoutMinutes  *int     `json:"session_timeout_minutes,omitempty"`
+}
+
+// GetPolicy retrieves the login policy of the organisation.
+//
+// Parameters:
+// - ctx: The context for the request.
+//
+// Returns:
+// - LoginPolicy: The current login policy.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	policy, err := policyClient.GetPolicy(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get login policy: %v", err)
+//	}
+//	log.Printf("Login Policy: %v", policy)
+func (c *Client) GetPolicy(ctx context.Context) (*LoginPolicy, error) {
+	apiResponse, err := generic.Get[generic.Response[LoginPolicy]](ctx, c.config, "/login-policy", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login policy: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// UpdatePolicy updates the login policy of the organisation.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - LoginPolicy: The updated login policy.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	maxFailedAttempts := 5
+//	policy, err := policyClient.UpdatePolicy(context.TODO(), &loginpolicy.UpdateLoginPolicyInput{
+//	    AllowedMethods:    []string{loginpolicy.MethodPassword, loginpolicy.MethodSSO},
+//	    MaxFailedAttempts: &maxFailedAttempts,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update login policy: %v", err)
+//	}
+//	log.Printf("Updated Login Policy: %v", policy)
+func (c *Client) UpdatePolicy(ctx context.Context, input *UpdateLoginPolicyInput) (*LoginPolicy, error) {
+	if input != nil {
+		for _, method := range input.AllowedMethods {
+			if method != MethodPassword && method != MethodSSO && method != MethodOTP {
+				return nil, fmt.Errorf("invalid login method %q", method)
+			}
+		}
+	}
+
+	apiResponse, err := generic.Patch[UpdateLoginPolicyInput, generic.Response[LoginPolicy]](ctx, c.config, "/login-policy", input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update login policy: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
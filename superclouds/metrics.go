@@ -0,0 +1,101 @@
+package superclouds
+
+import (
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WithMetrics records Prometheus metrics for every request made with the Config:
+//   - superclouds_api_requests_total{resource,method,status_code}: a counter of requests.
+//   - superclouds_api_request_duration_seconds{resource,method}: a histogram of request latency.
+//
+// Registration is idempotent, so several Configs may share a Registerer.
+// If another collector is already registered under one of these names,
+// NewConfigWithOptions returns the registration error.
+// Requests that fail without a response are counted with status_code "error".
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithMetrics(prometheus.DefaultRegisterer),
+//	)
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(c *Config) {
+		m, err := newMetrics(reg)
+		if err != nil {
+			c.setOptionErr(fmt.Errorf("failed to register metrics: %w", err))
+			return
+		}
+		c.metrics = m
+	}
+}
+
+type metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func newMetrics(reg prometheus.Registerer) (*metrics, error) {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "superclouds_api_requests_total",
+		Help: "Total number of requests made to the Superclouds API.",
+	}, []string{"resource", "method", "status_code"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "superclouds_api_request_duration_seconds",
+		Help:    "Latency of requests made to the Superclouds API.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"resource", "method"})
+
+	requests, err := register(reg, requests)
+	if err != nil {
+		return nil, err
+	}
+	duration, err = register(reg, duration)
+	if err != nil {
+		return nil, err
+	}
+	return &metrics{requests: requests, duration: duration}, nil
+}
+
+// register registers c with reg, or returns the collector already registered under the same name.
+func register[C prometheus.Collector](reg prometheus.Registerer, c C) (C, error) {
+	if err := reg.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(C); ok {
+				return existing, nil
+			}
+		}
+		return c, err
+	}
+	return c, nil
+}
+
+// metricsRoundTripper wraps a RoundTripper and records metrics for each request.
+type metricsRoundTripper struct {
+	next    http.RoundTripper
+	metrics *metrics
+	baseURL string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource, method := operationOf(req, t.baseURL)
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	t.metrics.duration.WithLabelValues(resource, method).Observe(time.Since(start).Seconds())
+	statusCode := "error"
+	if err == nil {
+		statusCode = strconv.Itoa(resp.StatusCode)
+	}
+	t.metrics.requests.WithLabelValues(resource, method, statusCode).Inc()
+
+	return resp, err
+}
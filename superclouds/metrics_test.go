@@ -0,0 +1,64 @@
+package superclouds_test
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsCountsRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	// The second Config registers the same metrics, which must not panic.
+	first := newTestConfig(t, srv, superclouds.WithMetrics(reg))
+	second := newTestConfig(t, srv, superclouds.WithMetrics(reg))
+
+	ctx := superclouds.WithOperation(context.Background(), "users", "list")
+	for _, cfg := range []*superclouds.Config{first, first, second} {
+		req, err := cfg.NewRequest(ctx, http.MethodGet, cfg.URL("/users"), nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		resp, err := cfg.Client.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	want := `
+# HELP superclouds_api_requests_total Total number of requests made to the Superclouds API.
+# TYPE superclouds_api_requests_total counter
+superclouds_api_requests_total{method="list",resource="users",status_code="200"} 3
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "superclouds_api_requests_total"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(reg, "superclouds_api_request_duration_seconds"); n != 1 {
+		t.Errorf("duration series = %d, want 1", n)
+	}
+}
+
+func TestMetricsRegistrationConflict(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	// A collector with the same name but other labels cannot be shared.
+	reg.MustRegister(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "superclouds_api_requests_total",
+		Help: "Total number of requests made to the Superclouds API.",
+	}, []string{"endpoint"}))
+
+	_, err := superclouds.NewConfigWithOptions("", "",
+		superclouds.WithToken("test-token"),
+		superclouds.WithMetrics(reg),
+	)
+	if err == nil || !strings.Contains(err.Error(), "failed to register metrics") {
+		t.Errorf("error = %v, want a metrics registration error", err)
+	}
+}
@@ -0,0 +1,144 @@
+package mfa
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"net/url"
+	"time"
+)
+
+// Client provides methods to run multi-factor authentication challenge flows against the Superclouds API.
+type Client struct {
+	config *superclouds.Config
+}
+
+// NewClient creates a new MFA Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	mfaClient := mfa.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg}
+}
+
+// ChallengeOutput defines the output structure for the InitiateChallenge method.
+type ChallengeOutput struct {
+	ChallengeID string    `json:"challenge_id"`
+	Method      string    `json:"method"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// VerifyOutput defines the output structure for the VerifyChallenge method.
+type VerifyOutput struct {
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at"`
+}
+
+// MFAStatus describes the MFA enrolment of a user.
+// LastVerifiedAt is nil if the user has never completed a challenge.
+type MFAStatus struct {
+	Enabled        bool       `json:"enabled"`
+	Methods        []string   `json:"methods"`
+	LastVerifiedAt *time.Time `json:"last_verified_at"`
+}
+
+// InitiateChallenge starts a new MFA challenge for the given user.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - userID: The ID of the user to challenge.
+//
+// Returns:
+// - ChallengeOutput: The challenge details, including the ID to verify against.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	challenge, err := mfaClient.InitiateChallenge(context.TODO(), "user-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to initiate challenge: %v", err)
+//	}
+//	log.Printf("Challenge: %v", challenge)
+func (c *Client) InitiateChallenge(ctx context.Context, userID string) (*ChallengeOutput, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	body := map[string]string{"user_id": userID}
+	apiResponse, err := generic.Post[map[string]string, generic.Response[ChallengeOutput]](ctx, c.config, "/mfa/challenges", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate challenge: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// VerifyChallenge submits the code supplied by the user for a pending challenge.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - challengeID: The ID returned by InitiateChallenge.
+// - code: The code supplied by the user.
+//
+// Returns:
+// - VerifyOutput: The result of the verification.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	result, err := mfaClient.VerifyChallenge(context.TODO(), challenge.ChallengeID, "123456")
+//	if err != nil {
+//	    log.Fatalf("Failed to verify challenge: %v", err)
+//	}
+//	log.Printf("Verified: %v", result.Verified)
+func (c *Client) VerifyChallenge(ctx context.Context, challengeID, code string) (*VerifyOutput, error) {
+	if challengeID == "" {
+		return nil, fmt.Errorf("challenge ID is required")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+
+	body := map[string]string{"code": code}
+	apiResponse, err := generic.Post[map[string]string, generic.Response[VerifyOutput]](ctx, c.config, "/mfa/challenges/"+url.PathEscape(challengeID)+"/verify", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify challenge: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// GetMFAStatus retrieves the MFA enrolment status of the given user.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - userID: The ID of the user.
+//
+// Returns:
+// - MFAStatus: The user's MFA status.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	status, err := mfaClient.GetMFAStatus(context.TODO(), "user-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to get MFA status: %v", err)
+//	}
+//	log.Printf("MFA Status: %v", status)
+func (c *Client) GetMFAStatus(ctx context.Context, userID string) (*MFAStatus, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	apiResponse, err := generic.Get[generic.Response[MFAStatus]](ctx, c.config, "/users/"+url.PathEscape(userID)+"/mfa", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MFA status: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
@@ -0,0 +1,203 @@
+// Package mirror sends a copy of each API call to a secondary endpoint, for
+// shadow testing a new API version against the current one.
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+	"time"
+)
+
+// shadowTimeout bounds each shadow request, which outlives the caller's request.
+const shadowTimeout = 30 * time.Second
+
+// Option configures a transport created by NewMirroringTransport.
+type Option func(*transport)
+
+// WithLogger sets the Logger that receives discrepancies and shadow failures.
+// By default they are not logged.
+func WithLogger(logger superclouds.Logger) Option {
+	return func(t *transport) {
+		t.logger = logger
+	}
+}
+
+// WithMethods sets the HTTP methods of the requests that are mirrored. By
+// default only GET, HEAD and OPTIONS requests are, so that writes are not
+// replayed against the shadow endpoint.
+//
+// Example usage:
+//
+//	mirror.NewMirroringTransport(next, shadowTransport, nil,
+//	    mirror.WithMethods(http.MethodGet, http.MethodPost),
+//	)
+func WithMethods(methods ...string) Option {
+	return func(t *transport) {
+		t.methods = make(map[string]bool, len(methods))
+		for _, method := range methods {
+			t.methods[method] = true
+		}
+	}
+}
+
+// WithMaxResponseBodyBytes sets the largest primary response body that is
+// read into memory for comparison; responses with larger bodies are returned
+// as they are and not compared. It defaults to
+// superclouds.DefaultMaxResponseBodyBytes, the limit of the SDK.
+func WithMaxResponseBodyBytes(n int64) Option {
+	return func(t *transport) {
+		t.maxResponseBodyBytes = n
+	}
+}
+
+// NewMirroringTransport returns an http.RoundTripper that sends each request
+// to primary and a copy of it to shadow. Only the primary response is
+// returned; the shadow response is compared with it in the background by
+// compare, and a non-nil result is logged. When compare is nil, responses
+// are expected to have the same status code and body. Only safe methods are
+// mirrored by default, see WithMethods. The bodies of mirrored requests and
+// of their primary responses, up to WithMaxResponseBodyBytes, are read into
+// memory. Installed with
+// superclouds.WithMiddleware, the primary is the SDK's own transport, so that
+// primary requests keep the SDK's authentication, retries and limits.
+//
+// Example usage:
+//
+//	shadowTransport := &http.Transport{...} // routed to the new API version
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+//	        return mirror.NewMirroringTransport(next, shadowTransport, nil)
+//	    }),
+//	)
+func NewMirroringTransport(primary, shadow http.RoundTripper, compare func(a, b *http.Response) error, opts ...Option) http.RoundTripper {
+	if compare == nil {
+		compare = compareStatusAndBody
+	}
+	t := &transport{
+		primary: primary,
+		shadow:  shadow,
+		compare: compare,
+		logger:  superclouds.NopLogger{},
+		methods: map[string]bool{
+			http.MethodGet:     true,
+			http.MethodHead:    true,
+			http.MethodOptions: true,
+		},
+		maxResponseBodyBytes: superclouds.DefaultMaxResponseBodyBytes,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// transport mirrors requests to a shadow RoundTripper.
+type transport struct {
+	primary http.RoundTripper
+	shadow  http.RoundTripper
+	compare func(a, b *http.Response) error
+	logger  superclouds.Logger
+	// methods are the methods of the requests mirrored, see WithMethods.
+	methods map[string]bool
+	// maxResponseBodyBytes bounds the primary bodies compared, see WithMaxResponseBodyBytes.
+	maxResponseBodyBytes int64
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.methods[req.Method] {
+		return t.primary.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+	}
+
+	resp, err := t.primary.RoundTrip(withBody(req.Context(), req, reqBody))
+	if err != nil {
+		return nil, err
+	}
+	// One byte past the limit is read to tell a body at the limit from a larger one.
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, t.maxResponseBodyBytes+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if int64(len(respBody)) > t.maxResponseBodyBytes {
+		// The caller reads the rest of the body from the connection; it is not buffered.
+		t.logger.Warn("response too large to mirror", "method", req.Method, "path", req.URL.Path)
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(respBody), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	primaryCopy := *resp
+	primaryCopy.Body = io.NopCloser(bytes.NewReader(respBody))
+	go t.mirror(req, reqBody, &primaryCopy)
+
+	return resp, nil
+}
+
+// mirror sends req to the shadow RoundTripper and compares its response with primary.
+func (t *transport) mirror(req *http.Request, reqBody []byte, primary *http.Response) {
+	// The shadow request must not be cancelled when the caller is done with the primary response.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), shadowTimeout)
+	defer cancel()
+
+	shadowResp, err := t.shadow.RoundTrip(withBody(ctx, req, reqBody))
+	if err != nil {
+		t.logger.Error("shadow request failed", "method", req.Method, "path", req.URL.Path, "error", err)
+		return
+	}
+	defer shadowResp.Body.Close()
+
+	if err := t.compare(primary, shadowResp); err != nil {
+		t.logger.Error("shadow response differs", "method", req.Method, "path", req.URL.Path, "error", err)
+	}
+}
+
+// withBody returns a copy of req with ctx and a fresh reader of body.
+func withBody(ctx context.Context, req *http.Request, body []byte) *http.Request {
+	clone := req.Clone(ctx)
+	if body != nil {
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+		clone.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	return clone
+}
+
+// compareStatusAndBody is the default compare function.
+func compareStatusAndBody(a, b *http.Response) error {
+	if a.StatusCode != b.StatusCode {
+		return fmt.Errorf("status %d != %d", a.StatusCode, b.StatusCode)
+	}
+	bodyA, err := io.ReadAll(a.Body)
+	if err != nil {
+		return fmt.Errorf("error reading primary body: %w", err)
+	}
+	bodyB, err := io.ReadAll(b.Body)
+	if err != nil {
+		return fmt.Errorf("error reading shadow body: %w", err)
+	}
+	if !bytes.Equal(bodyA, bodyB) {
+		return fmt.Errorf("bodies differ")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package mirror_test
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds/mirror"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// channelLogger sends the messages it receives, with their level, to a channel.
+type channelLogger chan string
+
+func (l channelLogger) Info(msg string, kvs ...interface{})  { l <- "INFO " + msg }
+func (l channelLogger) Debug(msg string, kvs ...interface{}) {}
+func (l channelLogger) Warn(msg string, kvs ...interface{})  { l <- "WARN " + msg }
+func (l channelLogger) Error(msg string, kvs ...interface{}) { l <- "ERROR " + msg }
+
+// shadowCall is a request received by the shadow server.
+type shadowCall struct {
+	method, uri, header, body string
+}
+
+// newMirroringClient returns a client whose requests go to a primary server
+// answering primaryBody and are mirrored to a shadow server answering
+// shadowBody. The shadow server reports the requests it receives on the
+// returned channel.
+func newMirroringClient(t *testing.T, primaryBody, shadowBody string, opts ...mirror.Option) (*http.Client, string, <-chan shadowCall) {
+	t.Helper()
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, primaryBody)
+	}))
+	t.Cleanup(primary.Close)
+
+	calls := make(chan shadowCall, 10)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls <- shadowCall{r.Method, r.URL.RequestURI(), r.Header.Get("X-Test"), string(body)}
+		io.WriteString(w, shadowBody)
+	}))
+	t.Cleanup(shadow.Close)
+	shadowURL, _ := url.Parse(shadow.URL)
+
+	// The shadow transport routes the mirrored requests to the shadow server.
+	shadowTransport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Host = shadowURL.Host
+		req.Host = ""
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := &http.Client{Transport: mirror.NewMirroringTransport(http.DefaultTransport, shadowTransport, nil, opts...)}
+	return client, primary.URL, calls
+}
+
+// receive returns the next value of ch, failing the test after a timeout.
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the shadow request")
+		panic("unreachable")
+	}
+}
+
+func TestMirrorSendsSameRequestAndLogsDifference(t *testing.T) {
+	logger := make(channelLogger, 10)
+	client, primaryURL, calls := newMirroringClient(t, `{"id":"1"}`, `{"id":"2"}`, mirror.WithLogger(logger))
+
+	req, _ := http.NewRequest(http.MethodGet, primaryURL+"/users?page=2", nil)
+	req.Header.Set("X-Test", "mirrored")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"id":"1"}` {
+		t.Errorf("response body = %s, want the primary body", body)
+	}
+
+	want := shadowCall{http.MethodGet, "/users?page=2", "mirrored", ""}
+	if got := receive(t, calls); got != want {
+		t.Errorf("shadow request = %+v, want %+v", got, want)
+	}
+	if msg := receive(t, logger); msg != "ERROR shadow response differs" {
+		t.Errorf("logged %q, want the difference", msg)
+	}
+}
+
+func TestMirrorSkipsWritesByDefault(t *testing.T) {
+	client, primaryURL, calls := newMirroringClient(t, "ok", "ok")
+
+	resp, err := client.Post(primaryURL+"/users", "application/json", strings.NewReader(`{"email":"a@example.com"}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// A mirrored GET sent afterwards shows what reached the shadow server.
+	resp, err = client.Get(primaryURL + "/users")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if got := receive(t, calls); got.method != http.MethodGet {
+		t.Errorf("shadow request = %+v, want only the GET", got)
+	}
+}
+
+func TestMirrorWithMethods(t *testing.T) {
+	client, primaryURL, calls := newMirroringClient(t, "ok", "ok", mirror.WithMethods(http.MethodPost))
+
+	resp, err := client.Post(primaryURL+"/users", "application/json", strings.NewReader(`{"email":"a@example.com"}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	want := shadowCall{http.MethodPost, "/users", "", `{"email":"a@example.com"}`}
+	if got := receive(t, calls); got != want {
+		t.Errorf("shadow request = %+v, want %+v", got, want)
+	}
+}
+
+func TestMirrorSkipsLargeResponses(t *testing.T) {
+	logger := make(channelLogger, 10)
+	primaryBody := strings.Repeat("a", 100)
+	client, primaryURL, _ := newMirroringClient(t, primaryBody, "", mirror.WithLogger(logger), mirror.WithMaxResponseBodyBytes(10))
+
+	resp, err := client.Get(primaryURL + "/users")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != primaryBody {
+		t.Errorf("response body = %d bytes, want %d", len(body), len(primaryBody))
+	}
+	if msg := receive(t, logger); msg != "WARN response too large to mirror" {
+		t.Errorf("logged %q, want the response too large", msg)
+	}
+}
@@ -0,0 +1,124 @@
+// Package nats publishes user change events to NATS JetStream.
+//
+// Events are avro.UserChangeEvent records encoded as JSON, published to one
+// subject per change type: {prefix}.created, {prefix}.deleted and
+// {prefix}.role_changed, where the prefix defaults to superclouds.users.
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/avro"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"time"
+)
+
+// DefaultSubjectPrefix is the subject prefix used when WithSubjectPrefix is not given.
+const DefaultSubjectPrefix = "superclouds.users"
+
+// Publisher publishes messages to JetStream. It is implemented by jetstream.JetStream.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, data []byte, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// EventPublisher publishes user change events to NATS JetStream.
+// It implements users.EventPublisher.
+type EventPublisher struct {
+	js            Publisher
+	stream        string
+	subjectPrefix string
+}
+
+var _ users.EventPublisher = (*EventPublisher)(nil)
+
+// Option configures an EventPublisher created by NewEventPublisher.
+type Option func(*EventPublisher)
+
+// WithStream makes the EventPublisher check that events are stored by the named stream.
+// Publishing fails when the subject is captured by another stream or by none.
+func WithStream(name string) Option {
+	return func(p *EventPublisher) {
+		p.stream = name
+	}
+}
+
+// WithSubjectPrefix sets the prefix of the subjects events are published to.
+func WithSubjectPrefix(prefix string) Option {
+	return func(p *EventPublisher) {
+		p.subjectPrefix = prefix
+	}
+}
+
+// NewEventPublisher creates an EventPublisher that publishes events with js.
+//
+// Example usage:
+//
+//	nc, err := natsgo.Connect(natsgo.DefaultURL)
+//	if err != nil {
+//	    log.Fatalf("Failed to connect to NATS: %v", err)
+//	}
+//	js, err := jetstream.New(nc)
+//	if err != nil {
+//	    log.Fatalf("Failed to create JetStream context: %v", err)
+//	}
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    users.WithEventPublisher(nats.NewEventPublisher(js, nats.WithStream("USERS"))),
+//	)
+func NewEventPublisher(js Publisher, opts ...Option) *EventPublisher {
+	p := &EventPublisher{
+		js:            js,
+		subjectPrefix: DefaultSubjectPrefix,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// PublishUserCreated publishes an avro.ChangeCreated event for user to {prefix}.created.
+func (p *EventPublisher) PublishUserCreated(ctx context.Context, user *users.UserOutput) error {
+	return p.publish(ctx, "created", &avro.UserChangeEvent{
+		Type:  avro.ChangeCreated,
+		Email: user.Email,
+		User:  &user.User,
+	})
+}
+
+// PublishUserDeleted publishes an avro.ChangeDeleted event to {prefix}.deleted.
+func (p *EventPublisher) PublishUserDeleted(ctx context.Context, email string) error {
+	return p.publish(ctx, "deleted", &avro.UserChangeEvent{
+		Type:  avro.ChangeDeleted,
+		Email: email,
+	})
+}
+
+// PublishRoleChanged publishes an avro.ChangeRoleChanged event to {prefix}.role_changed.
+func (p *EventPublisher) PublishRoleChanged(ctx context.Context, email, oldRole, newRole string) error {
+	return p.publish(ctx, "role_changed", &avro.UserChangeEvent{
+		Type:    avro.ChangeRoleChanged,
+		Email:   email,
+		OldRole: oldRole,
+		NewRole: newRole,
+	})
+}
+
+// publish stamps event with the current time and publishes it to the subject {prefix}.{suffix}.
+func (p *EventPublisher) publish(ctx context.Context, suffix string, event *avro.UserChangeEvent) error {
+	event.OccurredAt = time.Now()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event.Type, err)
+	}
+
+	var opts []jetstream.PublishOpt
+	if p.stream != "" {
+		opts = append(opts, jetstream.WithExpectStream(p.stream))
+	}
+	if _, err := p.js.Publish(ctx, p.subjectPrefix+"."+suffix, data, opts...); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
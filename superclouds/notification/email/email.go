@@ -0,0 +1,99 @@
+// Package email sends templated transactional emails, such as welcome and
+// password reset messages, through the Superclouds notification API.
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+)
+
+// Client provides methods to interact with the email notification endpoint of the Superclouds API.
+type Client struct {
+	config *superclouds.Config
+}
+
+// NewClient creates a new email Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	emailClient := email.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg}
+}
+
+// Attachment represents a file attached to a transactional email.
+// Content is sent base64-encoded in the request body.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+// SendEmailInput defines the input parameters for the SendEmail method.
+type SendEmailInput struct {
+	To           []string       `json:"to"`
+	TemplateID   string         `json:"template_id"`
+	TemplateData map[string]any `json:"template_data,omitempty"`
+	ReplyTo      string         `json:"reply_to,omitempty"`
+	Attachments  []Attachment   `json:"attachments,omitempty"`
+}
+
+// SendEmail sends a templated transactional email, such as a welcome or password reset message.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := emailClient.SendEmail(context.TODO(), &email.SendEmailInput{
+//	    To:         []string{"new.user@example.com"},
+//	    TemplateID: "welcome",
+//	    TemplateData: map[string]any{
+//	        "first_name": "John",
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to send email: %v", err)
+//	}
+//	log.Println("Sent Email")
+func (c *Client) SendEmail(ctx context.Context, input *SendEmailInput) error {
+	if input == nil || len(input.To) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+	if input.TemplateID == "" {
+		return fmt.Errorf("template ID is required")
+	}
+
+	reqBody, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+
+	req, err := c.config.NewRequest(ctx, http.MethodPost, c.config.URL("/notifications/email"), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.config.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := c.config.CheckResponse(resp); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package superclouds
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type operationKey struct{}
+
+type operation struct {
+	resource string
+	method   string
+}
+
+// WithOperation returns a copy of ctx that names the SDK operation a request belongs to,
+// such as resource "users" and method "list". The name is used to label
+// traces and metrics; clients set it before making their requests.
+func WithOperation(ctx context.Context, resource, method string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation{resource: resource, method: method})
+}
+
+// OperationFrom returns the operation named with WithOperation in ctx.
+// ok is false when ctx carries no operation.
+func OperationFrom(ctx context.Context) (resource, method string, ok bool) {
+	op, ok := ctx.Value(operationKey{}).(operation)
+	return op.resource, op.method, ok
+}
+
+// operationOf returns the operation name of req. Requests made without
+// WithOperation are named after the first path segment below the base URL
+// and the lower-cased HTTP method.
+func operationOf(req *http.Request, baseURL string) (resource, method string) {
+	if resource, method, ok := OperationFrom(req.Context()); ok {
+		return resource, method
+	}
+
+	path := req.URL.Path
+	if i := strings.Index(baseURL, "://"); i >= 0 {
+		if j := strings.Index(baseURL[i+3:], "/"); j >= 0 {
+			path = strings.TrimPrefix(path, baseURL[i+3+j:])
+		}
+	}
+	resource = strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+	return resource, strings.ToLower(req.Method)
+}
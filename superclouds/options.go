@@ -0,0 +1,150 @@
+package superclouds
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Config created by NewConfigWithOptions.
+// Options are applied in order, before the HTTP client is set up and the Config is validated.
+type Option func(*Config)
+
+// setOptionErr records err as the error of an option, unless an earlier option already failed.
+func (c *Config) setOptionErr(err error) {
+	if c.optionErr == nil {
+		c.optionErr = err
+	}
+}
+
+// WithBaseURL overrides the base URL of the Superclouds API.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithBaseURL("https://staging.superclouds.example/v1"),
+//	    superclouds.WithToken(superToken),
+//	)
+func WithBaseURL(u string) Option {
+	return func(c *Config) {
+		c.SuperURL = u
+	}
+}
+
+// WithToken sets the bearer token for API authorization.
+func WithToken(token string) Option {
+	return func(c *Config) {
+		c.SuperToken = token
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to make requests.
+// The client is used as-is, so it must already be set up for mutual TLS with the certificate and key.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.Client = client
+	}
+}
+
+// WithCACert sets the path to a PEM file of CA certificates used to verify the server,
+// for deployments that use a private CA. An empty path keeps the system certificate pool.
+func WithCACert(caCertPath string) Option {
+	return func(c *Config) {
+		c.CACertPath = caCertPath
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the server certificate.
+// This exposes the connection to man-in-the-middle attacks and must only be
+// used against test servers; a warning is logged through the configured Logger whenever it is enabled.
+// Prefer WithCACert for servers that use a private CA.
+func WithInsecureSkipVerify() Option {
+	return func(c *Config) {
+		c.insecureSkipVerify = true
+	}
+}
+
+// WithFieldMask sets the fields requested by list methods when their input does not set a field mask.
+// The fields are sent as a comma-separated fields query parameter, for example fields=id,email,role.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithFieldMask("id", "email", "role"),
+//	)
+func WithFieldMask(fields ...string) Option {
+	return func(c *Config) {
+		c.FieldMask = fields
+	}
+}
+
+// NewConfigWithOptions creates a new Config instance using the cert and key paths and the given options.
+// Because every setting is applied at construction time, the returned Config
+// never has to be mutated afterwards and is safe to share between goroutines.
+//
+// Parameters:
+// - certPath: The path to the SSL certificate file, or "" for bearer-only authentication.
+// - keyPath: The path to the SSL key file, or "" for bearer-only authentication.
+// - opts: The options to apply, such as WithToken and WithBaseURL.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithBaseURL("https://staging.superclouds.example/v1"),
+//	)
+//	if err != nil {
+//	    log.Fatalf("Failed to create config: %v", err)
+//	}
+func NewConfigWithOptions(certPath, keyPath string, opts ...Option) (*Config, error) {
+	cfg := &Config{
+		SuperURL:             apiBaseURL,
+		APIVersion:           DefaultAPIVersion,
+		CertPath:             certPath,
+		KeyPath:              keyPath,
+		RequestTimeout:       DefaultRequestTimeout,
+		MaxResponseBodyBytes: DefaultMaxResponseBodyBytes,
+		retryConfig:          RetryConfig{RetryOnRateLimit: true},
+		requestIDGenerator:   newRequestID,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.optionErr != nil {
+		return nil, cfg.optionErr
+	}
+
+	if cfg.Client == nil {
+		if cfg.insecureSkipVerify {
+			cfg.Logger().Error("server certificate verification is disabled; use WithCACert instead of WithInsecureSkipVerify in production")
+		}
+		client, cert, err := setupClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Client = client
+		cfg.cert = cert
+	}
+	installTransport(cfg)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WithRequestTimeout sets Config.RequestTimeout, the time allowed for each
+// request whose context has no deadline, including reading the response body.
+// A zero timeout disables it.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithRequestTimeout(2*time.Minute),
+//	)
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.RequestTimeout = timeout
+	}
+}
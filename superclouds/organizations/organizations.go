@@ -0,0 +1,364 @@
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OrganizationsClient provides methods to manage the organization of the authenticated user through the Superclouds API.
+type OrganizationsClient struct {
+	config *superclouds.Config
+}
+
+// NewOrganizationsClient creates a new OrganizationsClient instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	organizationsClient := organizations.NewOrganizationsClient(cfg)
+func NewOrganizationsClient(cfg *superclouds.Config) *OrganizationsClient {
+	return &OrganizationsClient{config: cfg}
+}
+
+// Organization represents the organization the authenticated user belongs to.
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Plan      string    `json:"plan"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// UpdateOrganizationInput defines the input parameters for the UpdateOrganization method.
+type UpdateOrganizationInput struct {
+	Name string `json:"name"`
+}
+
+// Validate checks that the input parameters are acceptable before a request is made.
+func (i *UpdateOrganizationInput) Validate() error {
+	if strings.TrimSpace(i.Name) == "" {
+		return fmt.Errorf("missing organization name")
+	}
+	return nil
+}
+
+// Member represents a user's membership of the organization.
+type Member struct {
+	User     users.User `json:"user"`
+	JoinedAt time.Time  `json:"joined_at,omitzero"`
+	Status   string     `json:"status"`
+}
+
+// ListMembersInput defines the input parameters for the ListMembers method.
+type ListMembersInput struct {
+	Size       int            `json:"size"`
+	Page       int            `json:"page"`
+	SearchTerm string         `json:"s"`
+	Role       users.RoleName `json:"role"`
+}
+
+// ListMembersOutput defines the output structure for the ListMembers method.
+// Total is the number of members matching the input across all pages; more
+// pages follow while PageNumber is less than Pages.
+type ListMembersOutput struct {
+	Members    []Member `json:"data"`
+	PageNumber int      `json:"page"`
+	Pages      int      `json:"pages"`
+	Size       int      `json:"size"`
+	Total      int      `json:"total"`
+}
+
+// InviteUserInput defines the input parameters for the InviteUser method.
+// Role is optional; PersonalMessage is added to the invitation email.
+type InviteUserInput struct {
+	Email           string         `json:"email"`
+	Role            users.RoleName `json:"role,omitempty"`
+	PersonalMessage string         `json:"personal_message,omitempty"`
+}
+
+// Validate checks that the input parameters are acceptable before a request is made.
+func (i *InviteUserInput) Validate() error {
+	if strings.TrimSpace(i.Email) == "" {
+		return fmt.Errorf("missing email")
+	}
+	if i.Role != "" && !i.Role.Valid() {
+		return fmt.Errorf("invalid role %q: must be one of %q, %q or %q", i.Role, users.RoleAdmin, users.RoleModify, users.RoleView)
+	}
+	return nil
+}
+
+// InvitationOutput represents an invitation to join the organization.
+// The invitation can no longer be accepted after ExpiresAt.
+type InvitationOutput struct {
+	InvitationID string    `json:"invitation_id"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`
+	Email        string    `json:"email"`
+}
+
+// ListInvitationsInput defines the input parameters for the ListInvitations method.
+type ListInvitationsInput struct {
+	Size int `json:"size"`
+	Page int `json:"page"`
+}
+
+// ListInvitationsOutput defines the output structure for the ListInvitations method.
+// The pagination fields are those of ListMembersOutput.
+type ListInvitationsOutput struct {
+	Invitations []InvitationOutput `json:"data"`
+	PageNumber  int                `json:"page"`
+	Pages       int                `json:"pages"`
+	Size        int                `json:"size"`
+	Total       int                `json:"total"`
+}
+
+// GetOrganization retrieves the organization of the authenticated user.
+//
+// Parameters:
+// - ctx: The context for the request.
+//
+// Returns:
+// - Organization: The organization's details.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	organization, err := organizationsClient.GetOrganization(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get organization: %v", err)
+//	}
+//	log.Printf("Organization: %v", organization)
+func (c *OrganizationsClient) GetOrganization(ctx context.Context) (*Organization, error) {
+	ctx = superclouds.WithOperation(ctx, "organizations", "get")
+	ctx = superclouds.WithResource(ctx, "organization", "")
+
+	apiResponse, err := generic.Get[generic.Response[Organization]](ctx, c.config, "/organization", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// UpdateOrganization updates the details of the organization of the authenticated user.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - Organization: The updated organization's details.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	organization, err := organizationsClient.UpdateOrganization(context.TODO(), &organizations.UpdateOrganizationInput{
+//	    Name: "Example Corp",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update organization: %v", err)
+//	}
+//	log.Printf("Updated Organization: %v", organization)
+func (c *OrganizationsClient) UpdateOrganization(ctx context.Context, input *UpdateOrganizationInput) (*Organization, error) {
+	ctx = superclouds.WithOperation(ctx, "organizations", "update")
+	ctx = superclouds.WithResource(ctx, "organization", "")
+
+	if input == nil {
+		input = &UpdateOrganizationInput{}
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	apiResponse, err := generic.Patch[UpdateOrganizationInput, generic.Response[Organization]](ctx, c.config, "/organization", input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// ListMembers retrieves a page of the members of the organization.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - ListMembersOutput: The members and the pagination details.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	membersOutput, err := organizationsClient.ListMembers(context.TODO(), &organizations.ListMembersInput{
+//	    Size: 10,
+//	    Page: 1,
+//	    Role: users.RoleAdmin,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to list members: %v", err)
+//	}
+//	log.Printf("Members: %v", membersOutput.Members)
+func (c *OrganizationsClient) ListMembers(ctx context.Context, input *ListMembersInput) (*ListMembersOutput, error) {
+	ctx = superclouds.WithOperation(ctx, "organizations", "list_members")
+
+	if input == nil {
+		input = &ListMembersInput{}
+	}
+
+	params := url.Values{}
+	if input.Size > 0 {
+		params.Add("size", fmt.Sprintf("%d", input.Size))
+	}
+	if input.Page > 0 {
+		params.Add("page", fmt.Sprintf("%d", input.Page))
+	}
+	if input.SearchTerm != "" {
+		params.Add("s", input.SearchTerm)
+	}
+	if input.Role != "" {
+		params.Add("role", string(input.Role))
+	}
+
+	apiResponse, err := generic.Get[generic.Response[[]Member]](ctx, c.config, "/organizations/members", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	return &ListMembersOutput{
+		Members:    apiResponse.Data,
+		PageNumber: apiResponse.Page,
+		Pages:      apiResponse.Pages,
+		Size:       apiResponse.Size,
+		Total:      apiResponse.Total,
+	}, nil
+}
+
+// InviteUser sends an invitation email to a person who does not have an
+// account yet. Unlike users.UsersClient.CreateUser, which requires the
+// account to exist in the identity provider, the user is created when the
+// invitation is accepted.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - InvitationOutput: The invitation that was sent.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	invitation, err := organizationsClient.InviteUser(context.TODO(), &organizations.InviteUserInput{
+//	    Email:           "new.user@example.com",
+//	    Role:            users.RoleModify,
+//	    PersonalMessage: "Welcome to the team!",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to invite user: %v", err)
+//	}
+//	log.Printf("Invitation: %v", invitation)
+func (c *OrganizationsClient) InviteUser(ctx context.Context, input *InviteUserInput) (*InvitationOutput, error) {
+	ctx = superclouds.WithOperation(ctx, "organizations", "invite_user")
+
+	if input == nil {
+		input = &InviteUserInput{}
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	apiResponse, err := generic.Post[InviteUserInput, generic.Response[InvitationOutput]](ctx, c.config, "/invitations", input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite user: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// RevokeInvitation revokes an invitation that has not been accepted yet.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - invitationID: The ID of the invitation to revoke.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := organizationsClient.RevokeInvitation(context.TODO(), invitation.InvitationID)
+//	if err != nil {
+//	    log.Fatalf("Failed to revoke invitation: %v", err)
+//	}
+func (c *OrganizationsClient) RevokeInvitation(ctx context.Context, invitationID string) error {
+	ctx = superclouds.WithOperation(ctx, "organizations", "revoke_invitation")
+	ctx = superclouds.WithResource(ctx, "invitation", invitationID)
+
+	if invitationID == "" {
+		return fmt.Errorf("missing invitation ID")
+	}
+
+	if err := generic.Delete(ctx, c.config, "/invitations/"+url.PathEscape(invitationID)); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	return nil
+}
+
+// ListInvitations retrieves a page of the pending invitations of the organization.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - ListInvitationsOutput: The invitations and the pagination details.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	invitationsOutput, err := organizationsClient.ListInvitations(context.TODO(), &organizations.ListInvitationsInput{
+//	    Size: 10,
+//	    Page: 1,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to list invitations: %v", err)
+//	}
+//	log.Printf("Invitations: %v", invitationsOutput.Invitations)
+func (c *OrganizationsClient) ListInvitations(ctx context.Context, input *ListInvitationsInput) (*ListInvitationsOutput, error) {
+	ctx = superclouds.WithOperation(ctx, "organizations", "list_invitations")
+
+	if input == nil {
+		input = &ListInvitationsInput{}
+	}
+
+	params := url.Values{}
+	if input.Size > 0 {
+		params.Add("size", fmt.Sprintf("%d", input.Size))
+	}
+	if input.Page > 0 {
+		params.Add("page", fmt.Sprintf("%d", input.Page))
+	}
+
+	apiResponse, err := generic.Get[generic.Response[[]InvitationOutput]](ctx, c.config, "/invitations", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	return &ListInvitationsOutput{
+		Invitations: apiResponse.Data,
+		PageNumber:  apiResponse.Page,
+		Pages:       apiResponse.Pages,
+		Size:        apiResponse.Size,
+		Total:       apiResponse.Total,
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Package pagination encodes page positions as opaque page tokens.
+//
+// A token is the URL-safe base64 encoding of a small JSON document. Callers
+// should treat tokens as opaque strings and only pass them back to the SDK.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// token is the JSON document encoded in a page token.
+type token struct {
+	Page  int               `json:"p"`
+	Size  int               `json:"s,omitempty"`
+	Extra map[string]string `json:"x,omitempty"`
+}
+
+// EncodeToken returns an opaque page token for the given page, page size and extra state.
+//
+// Example usage:
+//
+//	next := pagination.EncodeToken(2, 50, nil)
+func EncodeToken(page, size int, extra map[string]string) string {
+	// Marshaling a struct of ints and a string map cannot fail.
+	data, _ := json.Marshal(token{Page: page, Size: size, Extra: extra})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeToken returns the page, page size and extra state encoded in a token created by EncodeToken.
+//
+// Example usage:
+//
+//	page, size, _, err := pagination.DecodeToken(next)
+//	if err != nil {
+//	    log.Fatalf("Invalid page token: %v", err)
+//	}
+func DecodeToken(tok string) (page, size int, extra map[string]string, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(tok)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	var t token
+	if err := json.Unmarshal(data, &t); err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	if t.Page < 1 || t.Size < 0 {
+		return 0, 0, nil, fmt.Errorf("invalid page token: page %d, size %d", t.Page, t.Size)
+	}
+
+	return t.Page, t.Size, t.Extra, nil
+}
@@ -0,0 +1,190 @@
+// Package scroll reads every item of a list endpoint through a server-side
+// scroll context, as Elasticsearch's scroll API does. The server keeps the
+// position of the scan, so that full exports neither re-run the query for
+// each page, as page numbers do, nor hold the whole result in memory.
+package scroll
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultKeepAlive is how long the server keeps a scroll context between two calls by default.
+const DefaultKeepAlive = time.Minute
+
+// Option configures a Scroll created by New.
+type Option func(*options)
+
+type options struct {
+	keepAlive time.Duration
+	params    url.Values
+}
+
+// WithKeepAlive sets how long the server keeps the scroll context between
+// two calls; it defaults to DefaultKeepAlive and is rounded down to seconds.
+func WithKeepAlive(d time.Duration) Option {
+	return func(o *options) {
+		o.keepAlive = d
+	}
+}
+
+// WithParams adds query parameters to the request that opens the scroll,
+// such as the filters accepted by the list endpoint.
+func WithParams(params url.Values) Option {
+	return func(o *options) {
+		o.params = params
+	}
+}
+
+// response is the body of the scroll endpoints.
+type response[T any] struct {
+	generic.Response[[]T]
+	ScrollID string `json:"scroll_id"`
+}
+
+// nextInput is the body of the request for the next batch.
+type nextInput struct {
+	ScrollID  string `json:"scroll_id"`
+	KeepAlive int    `json:"keep_alive"`
+}
+
+// Scroll reads the items of a list endpoint batch by batch. It is not safe
+// for concurrent use. A Scroll must be closed once opened, so that the server
+// can free the scroll context before it expires.
+type Scroll[T any] struct {
+	config    *superclouds.Config
+	path      string
+	keepAlive time.Duration
+	params    url.Values
+
+	scrollID string
+	// pending is the first batch, returned with the response that opens the scroll.
+	pending []T
+	opened  bool
+	done    bool
+}
+
+// New creates a Scroll over the list endpoint at path, such as "/users".
+//
+// Example usage:
+//
+//	s := scroll.New[users.User](cfg, "/users", scroll.WithKeepAlive(2*time.Minute))
+//	if err := s.Open(ctx, 1000); err != nil {
+//	    log.Fatalf("Failed to open scroll: %v", err)
+//	}
+//	defer s.Close(ctx)
+//	for {
+//	    batch, err := s.Next(ctx)
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    if err != nil {
+//	        log.Fatalf("Failed to read users: %v", err)
+//	    }
+//	    writeUsers(batch)
+//	}
+func New[T any](cfg *superclouds.Config, path string, opts ...Option) *Scroll[T] {
+	o := options{keepAlive: DefaultKeepAlive}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Scroll[T]{config: cfg, path: path, keepAlive: o.keepAlive, params: o.params}
+}
+
+// keepAliveSeconds returns the keep-alive sent to the server.
+func (s *Scroll[T]) keepAliveSeconds() int {
+	return max(int(s.keepAlive/time.Second), 1)
+}
+
+// Open opens the scroll context on the server, with batches of size items.
+// The first batch is returned by the first call to Next.
+func (s *Scroll[T]) Open(ctx context.Context, size int) error {
+	ctx = superclouds.WithOperation(ctx, "scroll", "open")
+
+	if s.opened {
+		return fmt.Errorf("scroll already opened")
+	}
+	if size <= 0 {
+		return fmt.Errorf("invalid scroll size %d", size)
+	}
+
+	params := url.Values{}
+	for key, values := range s.params {
+		params[key] = append([]string(nil), values...)
+	}
+	params.Set("size", strconv.Itoa(size))
+	params.Set("scroll", strconv.Itoa(s.keepAliveSeconds()))
+
+	apiResponse, err := generic.Get[response[T]](ctx, s.config, s.path, params)
+	if err != nil {
+		return fmt.Errorf("failed to open scroll: %w", err)
+	}
+
+	s.opened = true
+	s.scrollID = apiResponse.ScrollID
+	s.pending = apiResponse.Data
+	s.done = apiResponse.ScrollID == ""
+	return nil
+}
+
+// Next returns the next batch of items. After the last batch, it returns io.EOF.
+func (s *Scroll[T]) Next(ctx context.Context) ([]T, error) {
+	ctx = superclouds.WithOperation(ctx, "scroll", "next")
+
+	if !s.opened {
+		return nil, fmt.Errorf("scroll not opened")
+	}
+	if s.pending != nil {
+		batch := s.pending
+		s.pending = nil
+		if len(batch) > 0 {
+			return batch, nil
+		}
+		s.done = true
+	}
+	if s.done {
+		return nil, io.EOF
+	}
+
+	apiResponse, err := generic.Post[nextInput, response[T]](ctx, s.config, "/scroll", &nextInput{
+		ScrollID:  s.scrollID,
+		KeepAlive: s.keepAliveSeconds(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scroll: %w", err)
+	}
+
+	if apiResponse.ScrollID != "" {
+		s.scrollID = apiResponse.ScrollID
+	}
+	if len(apiResponse.Data) == 0 {
+		s.done = true
+		return nil, io.EOF
+	}
+	return apiResponse.Data, nil
+}
+
+// Close frees the scroll context on the server. Closing a Scroll that is not
+// open does nothing.
+func (s *Scroll[T]) Close(ctx context.Context) error {
+	ctx = superclouds.WithOperation(ctx, "scroll", "close")
+
+	if !s.opened || s.scrollID == "" {
+		return nil
+	}
+	scrollID := s.scrollID
+	s.scrollID = ""
+	s.done = true
+	s.pending = nil
+
+	if err := generic.Delete(ctx, s.config, "/scroll/"+url.PathEscape(scrollID)); err != nil {
+		return fmt.Errorf("failed to close scroll: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package passwordpolicy
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+)
+
+// Client provides methods to manage the organisation password policy through the Superclouds API.
+type Client struct {
+	config *superclouds.Config
+}
+
+// NewClient creates a new password policy Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	policyClient := passwordpolicy.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg}
+}
+
+// PasswordPolicy describes the password requirements of an organisation.
+// A MaxAgeDays of zero means passwords never expire, and PreventReuse is the
+// number of previous passwords that cannot be reused.
+type PasswordPolicy struct {
+	MinLength        int  `json:"min_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSpecial   bool `json:"require_special"`
+	MaxAgeDays       int  `json:"max_age_days"`
+	PreventReuse     int  `json:"prevent_reuse"`
+}
+
+// UpdatePasswordPolicyInput defines the input parameters for the UpdatePolicy method.
+// Fields left nil are not changed.
+type UpdatePasswordPolicyInput struct {
+	MinLength        *int  `json:"min_length,omitempty"`
+	RequireUppercase *bool `json:"require_uppercase,omitempty"`
+	RequireLowercase *bool `json:"require_lowercase,omitempty"`
+	RequireDigit     *bool `json:"require_digit,omitempty"`
+	RequireSpecial   *bool `json:"require_special,omitempty"`
+	MaxAgeDays       *int  `json:"max_age_days,omitempty"`
+	PreventReuse     *int  `json:"prevent_reuse,omitempty"`
+}
+
+// GetPolicy retrieves the password policy of the organisation.
+//
+// Parameters:
+// - ctx: The context for the request.
+//
+// Returns:
+// - PasswordPolicy: The current password policy.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	policy, err := policyClient.GetPolicy(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get password policy: %v", err)
+//	}
+//	log.Printf("Password Policy: %v", policy)
+func (c *Client) GetPolicy(ctx context.Context) (*PasswordPolicy, error) {
+	apiResponse, err := generic.Get[generic.Response[PasswordPolicy]](ctx, c.config, "/password-policy", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password policy: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// UpdatePolicy updates the password policy of the organisation.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The input parameters for the request.
+//
+// Returns:
+// - PasswordPolicy: The updated password policy.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	minLength := 12
+//	requireDigit := true
+//	policy, err := policyClient.UpdatePolicy(context.TODO(), &passwordpolicy.UpdatePasswordPolicyInput{
+//	    MinLength:    &minLength,
+//	    RequireDigit: &requireDigit,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update password policy: %v", err)
+//	}
+//	log.Printf("Updated Password Policy: %v", policy)
+func (c *Client) UpdatePolicy(ctx context.Context, input *UpdatePasswordPolicyInput) (*PasswordPolicy, error) {
+	apiResponse, err := generic.Patch[UpdatePasswordPolicyInput, generic.Response[PasswordPolicy]](ctx, c.config, "/password-policy", input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update password policy: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
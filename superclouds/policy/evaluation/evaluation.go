@@ -0,0 +1,42 @@
+// Package evaluation evaluates a user's permissions locally, without calling the API.
+//
+// Permissions are strings of the form "resource:action", such as
+// "users:read". A "*" matches any sequence of characters, so "users:*"
+// allows every action on users, "*:read" allows reading every resource and
+// "*" allows everything.
+package evaluation
+
+import (
+	"path"
+)
+
+// UserPermissionsOutput holds the effective permissions of a user, in the
+// shape returned by the API, so that a response fetched once can be
+// evaluated any number of times.
+type UserPermissionsOutput struct {
+	UserID      string   `json:"user_id"`
+	Permissions []string `json:"permissions"`
+}
+
+// Evaluate reports whether any permission in perms allows action on resource.
+// Malformed permissions never match.
+//
+// Example usage:
+//
+//	if !evaluation.Evaluate(perms, "delete", "users") {
+//	    log.Fatalf("Permission denied")
+//	}
+func Evaluate(perms *UserPermissionsOutput, action, resource string) bool {
+	if perms == nil {
+		return false
+	}
+
+	name := resource + ":" + action
+	for _, permission := range perms.Permissions {
+		// path.Match fails only on malformed patterns, which are treated as not matching.
+		if ok, _ := path.Match(permission, name); ok {
+			return true
+		}
+	}
+	return false
+}
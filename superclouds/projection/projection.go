@@ -0,0 +1,62 @@
+// Package projection copies SDK values into small caller-defined structs that
+// only hold the fields the caller needs.
+//
+// The package was requested as superclouds/select, but select is a reserved
+// word in Go and cannot be used as a package name.
+package projection
+
+import (
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"reflect"
+)
+
+// Project copies the fields of user into the fields of dest that have the same name.
+// Fields of T without a counterpart in users.User are left untouched.
+// A field of T whose type cannot hold the value of its counterpart is an error.
+//
+// Example usage:
+//
+//	type contact struct {
+//	    Email     string
+//	    FirstName string
+//	}
+//
+//	var c contact
+//	if err := projection.Project(user, &c); err != nil {
+//	    log.Fatalf("Failed to project user: %v", err)
+//	}
+func Project[T any](user *users.User, dest *T) error {
+	if user == nil || dest == nil {
+		return fmt.Errorf("projection: user and dest must not be nil")
+	}
+
+	dst := reflect.ValueOf(dest).Elem()
+	if dst.Kind() != reflect.Struct {
+		return fmt.Errorf("projection: dest must point to a struct, got %s", dst.Type())
+	}
+
+	src := reflect.ValueOf(user).Elem()
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		value := src.FieldByName(field.Name)
+		if !value.IsValid() {
+			continue
+		}
+
+		switch {
+		case value.Type().AssignableTo(field.Type):
+			dst.Field(i).Set(value)
+		case value.Type().ConvertibleTo(field.Type) && value.Kind() == field.Type.Kind():
+			dst.Field(i).Set(value.Convert(field.Type))
+		default:
+			return fmt.Errorf("projection: field %s has type %s, cannot hold %s", field.Name, field.Type, value.Type())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+// Package pb contains the Go code generated from users.proto.
+// Use the ToProto and FromProto methods of the users package types to convert to and from these messages.
+package pb
+
+//go:generate protoc -I .. --go_out=. --go_opt=paths=source_relative users.proto
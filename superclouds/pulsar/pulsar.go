@@ -0,0 +1,101 @@
+// Package pulsar publishes user change events to Apache Pulsar.
+//
+// Events are avro.UserChangeEvent records in Avro binary format, keyed by the
+// email of the user so that the events of a user stay in order. The format
+// is the same as that of the kafka package, so either can be used as the
+// users.EventPublisher.
+package pulsar
+
+import (
+	"context"
+	"fmt"
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/avro"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"time"
+)
+
+// eventTypeProperty carries the change type of an event, so that consumers can filter without decoding it.
+const eventTypeProperty = "event-type"
+
+// MessageSender sends messages to a Pulsar topic. It is implemented by pulsar.Producer.
+type MessageSender interface {
+	Send(ctx context.Context, msg *pulsar.ProducerMessage) (pulsar.MessageID, error)
+}
+
+// EventPublisher publishes user change events to a Pulsar topic.
+// It implements users.EventPublisher.
+type EventPublisher struct {
+	sender   MessageSender
+	schemaID int
+}
+
+var _ users.EventPublisher = (*EventPublisher)(nil)
+
+// NewEventPublisher creates an EventPublisher that sends events with sender.
+// When schemaID is not zero, each message starts with the schema ID header of
+// the schema registry wire format, see avro.WithSchemaID.
+//
+// Example usage:
+//
+//	producer, err := client.CreateProducer(pulsargo.ProducerOptions{Topic: "user-events"})
+//	if err != nil {
+//	    log.Fatalf("Failed to create producer: %v", err)
+//	}
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    users.WithEventPublisher(pulsar.NewEventPublisher(producer, 0)),
+//	)
+func NewEventPublisher(sender MessageSender, schemaID int) *EventPublisher {
+	return &EventPublisher{sender: sender, schemaID: schemaID}
+}
+
+// PublishUserCreated publishes an avro.ChangeCreated event for user.
+func (p *EventPublisher) PublishUserCreated(ctx context.Context, user *users.UserOutput) error {
+	return p.publish(ctx, &avro.UserChangeEvent{
+		Type:  avro.ChangeCreated,
+		Email: user.Email,
+		User:  &user.User,
+	})
+}
+
+// PublishUserDeleted publishes an avro.ChangeDeleted event for the user with the given email.
+func (p *EventPublisher) PublishUserDeleted(ctx context.Context, email string) error {
+	return p.publish(ctx, &avro.UserChangeEvent{
+		Type:  avro.ChangeDeleted,
+		Email: email,
+	})
+}
+
+// PublishRoleChanged publishes an avro.ChangeRoleChanged event for the user with the given email.
+func (p *EventPublisher) PublishRoleChanged(ctx context.Context, email, oldRole, newRole string) error {
+	return p.publish(ctx, &avro.UserChangeEvent{
+		Type:    avro.ChangeRoleChanged,
+		Email:   email,
+		OldRole: oldRole,
+		NewRole: newRole,
+	})
+}
+
+// publish stamps event with the current time and sends it to Pulsar.
+func (p *EventPublisher) publish(ctx context.Context, event *avro.UserChangeEvent) error {
+	event.OccurredAt = time.Now()
+	payload, err := avro.MarshalUserChangeEvent(event)
+	if err != nil {
+		return err
+	}
+	if p.schemaID != 0 {
+		payload = avro.WithSchemaID(p.schemaID, payload)
+	}
+
+	_, err = p.sender.Send(ctx, &pulsar.ProducerMessage{
+		Key:        event.Email,
+		Payload:    payload,
+		Properties: map[string]string{eventTypeProperty: event.Type},
+		EventTime:  event.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package superclouds
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/time/rate"
+	"net/http"
+)
+
+// ErrRateLimitExceeded is returned when the context of a request ends before
+// the client-side rate limiter set with WithRateLimit lets the request through.
+var ErrRateLimitExceeded = errors.New("client-side rate limit exceeded")
+
+// WithRateLimit limits the requests sent with the Config to rps requests per
+// second, with bursts of up to burst requests. When burst is zero, int(rps)
+// is used, with a minimum of one. Requests wait for the limiter until their
+// context ends, in which case they fail with ErrRateLimitExceeded, wrapping
+// the error of the limiter, such as context.Canceled. Retries
+// sent by the SDK count against the limit too.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithRateLimit(10, 0),
+//	)
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Config) {
+		if burst <= 0 {
+			burst = max(int(rps), 1)
+		}
+		c.rateLimiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// rateLimitRoundTripper wraps a RoundTripper and waits for a rate limiter before each request.
+type rateLimitRoundTripper struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Wait fails as soon as the deadline of the context would pass before a token is available.
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRateLimitExceeded, err)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,116 @@
+// Package adaptive provides a rate limiter that follows the rate limit headers of the Superclouds API.
+//
+// After each response, the Limiter sets its rate to the requests remaining in
+// the current window divided by the seconds until the window resets, so that
+// requests slow down as the quota runs out instead of failing with HTTP 429.
+package adaptive
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// RemainingHeader is the header carrying the requests left in the current window.
+	RemainingHeader = "X-RateLimit-Remaining"
+	// ResetHeader is the header carrying the seconds until the window resets.
+	ResetHeader = "X-RateLimit-Reset"
+)
+
+// epochThreshold separates reset values given in seconds from values given
+// as a Unix time: no window lasts longer than this many seconds.
+const epochThreshold = 1e9
+
+// Limiter is a rate limiter whose rate is adjusted from API responses.
+// It is safe for concurrent use.
+type Limiter struct {
+	limiter *rate.Limiter
+	max     rate.Limit
+}
+
+// NewLimiter creates a Limiter that allows up to max requests per second with
+// the given burst, until the first response with rate limit headers is observed.
+// The rate is never raised above max.
+//
+// Example usage:
+//
+//	limiter := adaptive.NewLimiter(50, 5)
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithMiddleware(limiter.Transport),
+//	)
+func NewLimiter(max rate.Limit, burst int) *Limiter {
+	return &Limiter{
+		limiter: rate.NewLimiter(max, burst),
+		max:     max,
+	}
+}
+
+// Wait blocks until the Limiter allows a request or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// Limit returns the current rate, in requests per second.
+func (l *Limiter) Limit() rate.Limit {
+	return l.limiter.Limit()
+}
+
+// Observe adjusts the rate from the rate limit headers of resp.
+// Responses without both headers leave the rate unchanged.
+func (l *Limiter) Observe(resp *http.Response) {
+	remaining, err := strconv.ParseFloat(resp.Header.Get(RemainingHeader), 64)
+	if err != nil || remaining < 0 {
+		return
+	}
+	reset, err := strconv.ParseFloat(resp.Header.Get(ResetHeader), 64)
+	if err != nil || reset < 0 {
+		return
+	}
+	if reset > epochThreshold {
+		reset = time.Until(time.Unix(int64(reset), 0)).Seconds()
+	}
+
+	if reset <= 0 {
+		// The window resets now; keep the current rate.
+		return
+	}
+
+	// A zero rate would block forever: with no requests left, allow one
+	// request per window so that the next one is sent once the window resets.
+	l.limiter.SetLimit(min(rate.Limit(max(remaining, 1)/reset), l.max))
+}
+
+// Transport returns an http.RoundTripper that waits on the Limiter before
+// each request and adjusts it from each response. It has the signature of a
+// middleware, so that it can be passed to superclouds.WithMiddleware to wrap
+// the SDK's transport. When next is nil, http.DefaultTransport is used.
+func (l *Limiter) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &roundTripper{next: next, limiter: l}
+}
+
+// roundTripper paces requests with a Limiter.
+type roundTripper struct {
+	next    http.RoundTripper
+	limiter *Limiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.limiter.Observe(resp)
+	return resp, nil
+}
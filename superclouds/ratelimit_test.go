@@ -0,0 +1,51 @@
+package superclouds_test
+
+import (
+	"context"
+	"errors"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitPacesRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv, superclouds.WithRateLimit(5, 1))
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if _, err := get(t, cfg, "/users/me"); err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+	// The first request takes the single token of the burst; the other nine
+	// wait 200ms each.
+	if elapsed := time.Since(start); elapsed < 1800*time.Millisecond {
+		t.Errorf("10 requests at 5 rps took %v, want at least 1.8s", elapsed)
+	}
+}
+
+func TestRateLimitExceededWrapsContextError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv, superclouds.WithRateLimit(1, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := cfg.NewRequest(ctx, http.MethodGet, cfg.URL("/users/me"), nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	_, err = cfg.Client.Do(req)
+	if !errors.Is(err, superclouds.ErrRateLimitExceeded) {
+		t.Errorf("error = %v, want ErrRateLimitExceeded", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want it to wrap context.Canceled", err)
+	}
+}
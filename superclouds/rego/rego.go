@@ -0,0 +1,161 @@
+// Package rego evaluates Open Policy Agent policies, written in Rego, on the
+// data returned by the Superclouds API, so that operators can deny responses
+// their policies do not allow, such as users of an unapproved email domain.
+//
+// Policies are written in package superclouds and define a boolean rule
+// allow, which receives the data as input. Data is allowed only when allow
+// is true; when it is undefined, the data is denied.
+//
+//	package superclouds
+//
+//	default allow := false
+//
+//	allow if {
+//	    every user in input.data {
+//	        endswith(user.email, "@example.com")
+//	    }
+//	}
+package rego
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+)
+
+// query is the rule a policy is evaluated with.
+const query = "data.superclouds.allow"
+
+// ErrPolicyDenied is returned for data that a policy does not allow.
+var ErrPolicyDenied = errors.New("denied by policy")
+
+// compile prepares policy for evaluation.
+func compile(ctx context.Context, policy string) (rego.PreparedEvalQuery, error) {
+	pq, err := rego.New(
+		rego.Query(query),
+		rego.Module("policy.rego", policy),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("failed to compile policy: %w", err)
+	}
+	return pq, nil
+}
+
+// evaluate reports whether pq allows input, which must be JSON-compatible.
+func evaluate(ctx context.Context, pq rego.PreparedEvalQuery, input interface{}) error {
+	rs, err := pq.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return fmt.Errorf("failed to evaluate policy: %w", err)
+	}
+	if !rs.Allowed() {
+		return ErrPolicyDenied
+	}
+	return nil
+}
+
+// PolicyFilter evaluates policy with data as its input, and returns data if
+// the policy allows it. Data is passed to the policy as it is encoded to
+// JSON, so its fields have the names of their json tags.
+//
+// Parameters:
+// - data: The data to evaluate, such as the output of ListUsers.
+// - policy: The Rego policy, see the package documentation.
+//
+// Returns:
+// - T: data, if the policy allows it.
+// - error: ErrPolicyDenied if the policy denies data, or any error encountered compiling or evaluating the policy.
+//
+// Example usage:
+//
+//	output, err := usersClient.ListUsers(context.TODO(), &users.ListUsersInput{})
+//	if err != nil {
+//	    log.Fatalf("Failed to list users: %v", err)
+//	}
+//	output, err = rego.PolicyFilter(output, policy)
+//	if err != nil {
+//	    log.Fatalf("Failed to apply policy: %v", err)
+//	}
+func PolicyFilter[T any](data T, policy string) (T, error) {
+	var zero T
+	ctx := context.Background()
+
+	pq, err := compile(ctx, policy)
+	if err != nil {
+		return zero, err
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return zero, fmt.Errorf("failed to encode data: %w", err)
+	}
+	var input interface{}
+	if err := json.Unmarshal(b, &input); err != nil {
+		return zero, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	if err := evaluate(ctx, pq, input); err != nil {
+		return zero, err
+	}
+	return data, nil
+}
+
+// WithOPAPolicy makes the SDK evaluate policy on the body of each successful
+// JSON response, before it is decoded. Responses the policy denies fail with
+// an error matching ErrPolicyDenied. When policy does not compile, every
+// request fails with the compilation error.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    rego.WithOPAPolicy(policy),
+//	)
+func WithOPAPolicy(policy string) superclouds.Option {
+	pq, err := compile(context.Background(), policy)
+	return superclouds.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return &policyRoundTripper{next: next, query: pq, err: err}
+	})
+}
+
+// policyRoundTripper denies the responses that a policy does not allow.
+type policyRoundTripper struct {
+	next  http.RoundTripper
+	query rego.PreparedEvalQuery
+	// err is the error of compiling the policy.
+	err error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *policyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 || resp.Body == nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var input interface{}
+	if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &input) != nil {
+		// Only JSON responses are subject to the policy.
+		return resp, nil
+	}
+	if err := evaluate(req.Context(), t.query, input); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,71 @@
+package superclouds
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	supercontext "github.com/superclouds/super-sdk-go-v1/superclouds/context"
+	"io"
+	"net/http"
+)
+
+// Headers set from the RequestContext carried by the request context.
+const (
+	traceIDHeader    = "X-Trace-ID"
+	actorEmailHeader = "X-Actor-Email"
+	orgIDHeader      = "X-Org-ID"
+)
+
+// ErrRequestBodyTooLarge is returned when a request body exceeds Config.MaxRequestBodyBytes.
+var ErrRequestBodyTooLarge = errors.New("request body too large")
+
+// NewRequest creates a request to the Superclouds API with the headers shared by every SDK call:
+// the JSON content type, the bearer token, and the fields of any RequestContext stored in ctx.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - method: The HTTP method.
+// - url: The full request URL.
+// - body: The request body, or nil.
+//
+// Returns:
+// - *http.Request: The request, ready to be sent with c.Client.
+// - error: Any error encountered while creating the request, including ErrRequestBodyTooLarge.
+func (c *Config) NewRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	if body != nil && c.MaxRequestBodyBytes > 0 {
+		// Reading one byte past the limit tells a body at the limit from a larger one.
+		data, err := io.ReadAll(io.LimitReader(body, c.MaxRequestBodyBytes+1))
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		if int64(len(data)) > c.MaxRequestBodyBytes {
+			return nil, ErrRequestBodyTooLarge
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.SuperToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.SuperToken)
+	}
+
+	if rc, ok := supercontext.RequestContextFrom(ctx); ok {
+		if rc.TraceID != "" {
+			req.Header.Set(traceIDHeader, rc.TraceID)
+		}
+		if rc.ActorEmail != "" {
+			req.Header.Set(actorEmailHeader, rc.ActorEmail)
+		}
+		if rc.OrgID != "" {
+			req.Header.Set(orgIDHeader, rc.OrgID)
+		}
+	}
+
+	return req, nil
+}
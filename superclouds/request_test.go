@@ -0,0 +1,34 @@
+package superclouds_test
+
+import (
+	"context"
+	"errors"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMaxRequestBodyBytes(t *testing.T) {
+	cfg, err := superclouds.NewConfigWithOptions("", "", superclouds.WithToken("test-token"))
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	cfg.MaxRequestBodyBytes = 100
+
+	_, err = cfg.NewRequest(context.Background(), http.MethodPost, cfg.URL("/users"), strings.NewReader(strings.Repeat("x", 101)))
+	if !errors.Is(err, superclouds.ErrRequestBodyTooLarge) {
+		t.Errorf("error for a 101-byte body = %v, want ErrRequestBodyTooLarge", err)
+	}
+
+	body := strings.Repeat("x", 100)
+	req, err := cfg.NewRequest(context.Background(), http.MethodPost, cfg.URL("/users"), strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest with a 100-byte body: %v", err)
+	}
+	sent, _ := io.ReadAll(req.Body)
+	if string(sent) != body {
+		t.Errorf("request body has %d bytes, want %d", len(sent), len(body))
+	}
+}
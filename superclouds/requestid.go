@@ -0,0 +1,35 @@
+package superclouds
+
+import (
+	"github.com/google/uuid"
+)
+
+// WithRequestIDGenerator replaces the generator of the X-Request-ID header.
+// Every request is sent with a new ID, a random UUID by default, so that a
+// call can be found in the server's logs. When gen is nil, random UUIDs are
+// used. Requests that already have the header keep it, and the SDK's retries
+// of a request reuse its ID. The ID of a failed request is reported in
+// APIError.RequestID.
+//
+// Example usage:
+//
+//	var sequence atomic.Int64
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithRequestIDGenerator(func() string {
+//	        return fmt.Sprintf("billing-%d", sequence.Add(1))
+//	    }),
+//	)
+func WithRequestIDGenerator(gen func() string) Option {
+	return func(c *Config) {
+		if gen == nil {
+			gen = newRequestID
+		}
+		c.requestIDGenerator = gen
+	}
+}
+
+// newRequestID returns a random UUID, the default request ID.
+func newRequestID() string {
+	return uuid.New().String()
+}
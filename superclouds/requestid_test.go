@@ -0,0 +1,56 @@
+package superclouds_test
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDHeader(t *testing.T) {
+	var requestID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get("X-Request-ID")
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv)
+
+	if _, err := get(t, cfg, "/users/me"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if requestID == "" {
+		t.Errorf("X-Request-ID header not set")
+	}
+}
+
+func TestRequestIDReusedAcrossRetries(t *testing.T) {
+	var requestIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		if len(requestIDs) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	generated := 0
+	cfg := newTestConfig(t, srv, superclouds.WithRequestIDGenerator(func() string {
+		generated++
+		return "request-id"
+	}))
+
+	status, err := get(t, cfg, "/users/me")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	if len(requestIDs) != 2 || requestIDs[0] != "request-id" || requestIDs[1] != "request-id" {
+		t.Errorf("X-Request-ID headers = %q, want the same ID on both attempts", requestIDs)
+	}
+	if generated != 1 {
+		t.Errorf("generated IDs = %d, want 1", generated)
+	}
+}
@@ -0,0 +1,138 @@
+package superclouds
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrResponseBodyTooLarge is returned when a response body exceeds Config.MaxResponseBodyBytes.
+var ErrResponseBodyTooLarge = errors.New("response body too large")
+
+// LimitResponseBody returns a reader of body that fails with
+// ErrResponseBodyTooLarge once more than c.MaxResponseBodyBytes bytes have
+// been read. The SDK reads every response body through it; it is exported
+// for callers that send their own requests with c.Client.
+func (c *Config) LimitResponseBody(body io.Reader) io.Reader {
+	if c.MaxResponseBodyBytes <= 0 {
+		return body
+	}
+	// One byte past the limit is read to tell a body at the limit from a larger one.
+	return &limitedReader{r: body, remaining: c.MaxResponseBodyBytes + 1}
+}
+
+// limitedReader reads from r until remaining reaches zero, which means that
+// r holds more bytes than the limit.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, ErrResponseBodyTooLarge
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining <= 0 {
+		// The last byte read is past the limit.
+		return n - 1, ErrResponseBodyTooLarge
+	}
+	return n, err
+}
+
+// WithResponseDecoder sets the function that decodes the JSON response bodies
+// of successful requests into v, for example to validate them first. It is
+// called once per body and must return io.EOF for an empty body, as
+// json.Decoder does. A nil decode restores the default, encoding/json.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithResponseDecoder(func(r io.Reader, v interface{}) error {
+//	        return json.NewDecoder(r).Decode(v)
+//	    }),
+//	)
+func WithResponseDecoder(decode func(r io.Reader, v interface{}) error) Option {
+	return func(c *Config) {
+		c.responseDecoder = decode
+	}
+}
+
+// DecodeResponse decodes the JSON response body r into v, through LimitResponseBody
+// and the decoder set with WithResponseDecoder, if any. An empty body yields io.EOF.
+func (c *Config) DecodeResponse(r io.Reader, v interface{}) error {
+	r = c.LimitResponseBody(r)
+	if c.responseDecoder != nil {
+		return c.responseDecoder(r, v)
+	}
+	return json.NewDecoder(r).Decode(v)
+}
+
+// CheckResponse returns nil for a 2xx response, and otherwise the error
+// matching its status: a NotFoundError for 404, a ConflictError for 409 and
+// a ValidationError for 422, with the details decoded from the body, and
+// ErrPreconditionFailed for 412.
+// Other statuses yield an *APIError.
+// The error is passed through the translator set with WithErrorTranslator, if any.
+//
+// On error, the body is drained and closed so that the connection can be
+// reused; callers only read resp.Body when CheckResponse returns nil.
+func (c *Config) CheckResponse(resp *http.Response) error {
+	err := c.checkResponse(resp)
+	if err == nil {
+		return nil
+	}
+	drainAndClose(resp.Body)
+	if c.errorTranslator != nil {
+		err = c.errorTranslator(err)
+	}
+	return err
+}
+
+// checkResponse returns the error matching the status of resp, see CheckResponse.
+func (c *Config) checkResponse(resp *http.Response) error {
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		if resp.Request == nil {
+			return NotFoundError{}
+		}
+		return NewNotFoundError(resp.Request.Context())
+	case resp.StatusCode == http.StatusConflict:
+		// A body that cannot be decoded still yields a ConflictError, without details.
+		var conflictErr ConflictError
+		json.NewDecoder(c.LimitResponseBody(resp.Body)).Decode(&conflictErr)
+		return conflictErr
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case resp.StatusCode == http.StatusUnprocessableEntity:
+		var validationErr ValidationError
+		json.NewDecoder(c.LimitResponseBody(resp.Body)).Decode(&validationErr)
+		return validationErr
+	}
+
+	// The API reports errors in the message field of its envelope, or in an error field.
+	var body struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	json.NewDecoder(c.LimitResponseBody(resp.Body)).Decode(&body)
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: body.Message}
+	if apiErr.Message == "" {
+		apiErr.Message = body.Error
+	}
+	// The API echoes the request ID, or assigns one when the request has none.
+	apiErr.RequestID = resp.Header.Get(requestIDHeader)
+	if apiErr.RequestID == "" && resp.Request != nil {
+		apiErr.RequestID = resp.Request.Header.Get(requestIDHeader)
+	}
+	return apiErr
+}
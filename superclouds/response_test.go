@@ -0,0 +1,71 @@
+package superclouds_test
+
+import (
+	"context"
+	"errors"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckResponseNotFoundWithoutRequest(t *testing.T) {
+	cfg, err := superclouds.NewConfigWithOptions("", "", superclouds.WithToken("test-token"))
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	err = cfg.CheckResponse(&http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody})
+	var notFoundErr superclouds.NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("error = %v, want a NotFoundError", err)
+	}
+	if notFoundErr != (superclouds.NotFoundError{}) {
+		t.Errorf("NotFoundError = %+v, want no resource", notFoundErr)
+	}
+}
+
+func TestCheckResponseReusesConnection(t *testing.T) {
+	var newConns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		// The padding arrives after the transport has given up draining the
+		// body on its own, so only CheckResponse draining it keeps the
+		// connection alive.
+		w.Write([]byte(`{"message":"invalid request"}`))
+		w.(http.Flusher).Flush()
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(strings.Repeat(" ", 1024)))
+	}))
+	srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			newConns.Add(1)
+		}
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+	cfg := newTestConfig(t, srv)
+
+	for i := 0; i < 2; i++ {
+		req, err := cfg.NewRequest(context.Background(), http.MethodGet, cfg.URL("/users/1"), nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		resp, err := cfg.Client.Do(req)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		var apiErr *superclouds.APIError
+		if err := cfg.CheckResponse(resp); !errors.As(err, &apiErr) {
+			t.Fatalf("CheckResponse = %v, want an APIError", err)
+		}
+	}
+
+	if got := newConns.Load(); got != 1 {
+		t.Errorf("new connections = %d, want 1", got)
+	}
+}
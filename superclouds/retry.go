@@ -0,0 +1,95 @@
+package superclouds
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig controls which failed requests the SDK retries.
+type RetryConfig struct {
+	// RetryOnRateLimit retries a request once after an HTTP 429 response,
+	// once the delay given by its Retry-After header has passed. It is
+	// enabled by default.
+	RetryOnRateLimit bool
+}
+
+// WithRetryConfig sets the retry behaviour of the SDK.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithRetryConfig(superclouds.RetryConfig{RetryOnRateLimit: false}),
+//	)
+func WithRetryConfig(rc RetryConfig) Option {
+	return func(c *Config) {
+		c.retryConfig = rc
+	}
+}
+
+// RateLimitError is returned when the API rejects a request with HTTP 429
+// and the request was not, or could no longer be, retried.
+type RateLimitError struct {
+	// RetryAfter is the delay requested by the API before the next attempt; zero when the API gave none.
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter == 0 {
+		return "rate limit exceeded"
+	}
+	return fmt.Sprintf("rate limit exceeded, retry after %s", e.RetryAfter)
+}
+
+// parseRetryAfter returns the delay of a Retry-After header value, given
+// either in seconds or as an HTTP date. It returns zero for invalid values
+// and dates in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+// retryRateLimited handles an HTTP 429 response to req: unless retries are
+// disabled, it waits for the Retry-After delay and sends req once more. A
+// request that still ends with HTTP 429 returns a *RateLimitError.
+func (t *transport) retryRateLimited(req *http.Request, resp *http.Response) (*http.Response, error) {
+	rateLimitErr := &RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	drainAndClose(resp.Body)
+
+	if !t.retryConfig.RetryOnRateLimit {
+		return nil, rateLimitErr
+	}
+	retry, ok := rewind(req)
+	if !ok {
+		return nil, rateLimitErr
+	}
+	// There is no point in waiting for a retry that the deadline would cut short.
+	if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < rateLimitErr.RetryAfter {
+		return nil, rateLimitErr
+	}
+
+	t.logger.Info("retrying request after 429", "method", req.Method, "path", req.URL.Path, "retry_after", rateLimitErr.RetryAfter)
+	timer := time.NewTimer(rateLimitErr.RetryAfter)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-timer.C:
+	}
+
+	resp, err := t.send(retry)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+	rateLimitErr = &RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	drainAndClose(resp.Body)
+	return nil, rateLimitErr
+}
@@ -0,0 +1,61 @@
+package superclouds_test
+
+import (
+	"errors"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterRateLimit(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv)
+
+	start := time.Now()
+	status, err := get(t, cfg, "/users/me")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the Retry-After delay of 1s", elapsed)
+	}
+}
+
+func TestRateLimitErrorWithoutRetry(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv, superclouds.WithRetryConfig(superclouds.RetryConfig{RetryOnRateLimit: false}))
+
+	_, err := get(t, cfg, "/users/me")
+	var rateLimitErr *superclouds.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("error = %v, want a *RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %v, want 30s", rateLimitErr.RetryAfter)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
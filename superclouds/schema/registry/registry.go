@@ -0,0 +1,142 @@
+// Package registry manages the user schema of an organization: the custom
+// attributes that its users carry in addition to the standard fields.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+)
+
+// Client provides methods to manage the organization's user schema through the Superclouds API.
+type Client struct {
+	config *superclouds.Config
+}
+
+// NewClient creates a new registry Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	registryClient := registry.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg}
+}
+
+// Attribute types accepted in the Type field of AttributeDefinition.
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeBool     = "bool"
+	TypeDatetime = "datetime"
+)
+
+// AttributeDefinition defines a custom user attribute.
+// DefaultValue, when set, must be of the attribute's type; Validators name
+// the server-side validators applied to the attribute's values.
+type AttributeDefinition struct {
+	Name         string      `json:"name"`
+	Type         string      `json:"type"`
+	Required     bool        `json:"required"`
+	DefaultValue interface{} `json:"default_value,omitempty"`
+	Validators   []string    `json:"validators,omitempty"`
+}
+
+// UserSchema is the organization's user schema.
+type UserSchema struct {
+	CustomAttributes []AttributeDefinition `json:"custom_attributes"`
+}
+
+// UpdateUserSchemaInput defines the input parameters for the UpdateUserSchema method.
+// CustomAttributes replaces the organization's current set of custom attributes.
+type UpdateUserSchemaInput struct {
+	CustomAttributes []AttributeDefinition `json:"custom_attributes"`
+}
+
+// Validate checks that every attribute has a name, unique within the input, and a known type.
+func (i *UpdateUserSchemaInput) Validate() error {
+	seen := make(map[string]bool, len(i.CustomAttributes))
+	for _, attr := range i.CustomAttributes {
+		if attr.Name == "" {
+			return fmt.Errorf("attribute name is required")
+		}
+		if seen[attr.Name] {
+			return fmt.Errorf("duplicate attribute %q", attr.Name)
+		}
+		seen[attr.Name] = true
+
+		switch attr.Type {
+		case TypeString, TypeInt, TypeBool, TypeDatetime:
+		default:
+			return fmt.Errorf("invalid type %q for attribute %q: must be one of %q, %q, %q or %q",
+				attr.Type, attr.Name, TypeString, TypeInt, TypeBool, TypeDatetime)
+		}
+	}
+	return nil
+}
+
+// GetUserSchema retrieves the organization's user schema.
+//
+// Parameters:
+// - ctx: The context for the request.
+//
+// Returns:
+// - UserSchema: The organization's custom attribute definitions.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	schema, err := registryClient.GetUserSchema(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get user schema: %v", err)
+//	}
+//	for _, attr := range schema.CustomAttributes {
+//	    log.Printf("Attribute %s of type %s", attr.Name, attr.Type)
+//	}
+func (c *Client) GetUserSchema(ctx context.Context) (*UserSchema, error) {
+	ctx = superclouds.WithOperation(ctx, "user_schema", "get")
+
+	apiResponse, err := generic.Get[generic.Response[UserSchema]](ctx, c.config, "/users/schema", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user schema: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// UpdateUserSchema replaces the organization's custom attribute definitions.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - input: The new set of custom attributes.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := registryClient.UpdateUserSchema(context.TODO(), &registry.UpdateUserSchemaInput{
+//	    CustomAttributes: []registry.AttributeDefinition{
+//	        {Name: "department", Type: registry.TypeString, Required: true},
+//	        {Name: "hireDate", Type: registry.TypeDatetime},
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update user schema: %v", err)
+//	}
+func (c *Client) UpdateUserSchema(ctx context.Context, input *UpdateUserSchemaInput) error {
+	ctx = superclouds.WithOperation(ctx, "user_schema", "update")
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := generic.Patch[UpdateUserSchemaInput, struct{}](ctx, c.config, "/users/schema", input); err != nil {
+		return fmt.Errorf("failed to update user schema: %w", err)
+	}
+
+	return nil
+}
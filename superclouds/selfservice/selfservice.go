@@ -0,0 +1,177 @@
+package selfservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/url"
+	"time"
+)
+
+// Client provides the operations a user can perform on their own account, without admin involvement.
+// Every method acts on the identity the configured token belongs to.
+type Client struct {
+	config *superclouds.Config
+	users  *users.UsersClient
+}
+
+// NewClient creates a new self-service Client instance with the provided configuration.
+//
+// Parameters:
+// - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+//
+// Example usage:
+//
+//	selfClient := selfservice.NewClient(cfg)
+func NewClient(cfg *superclouds.Config) *Client {
+	return &Client{config: cfg, users: users.NewUsersClient(cfg)}
+}
+
+// APIKey describes an API key owned by the authenticated user.
+// The secret itself is only returned when the key is created and is never listed.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+// Session describes an active login session of the authenticated user.
+// Current is true for the session the request was made from.
+type Session struct {
+	ID         string    `json:"id"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+// GetUser retrieves the profile of the authenticated user.
+//
+// Example usage:
+//
+//	me, err := selfClient.GetUser(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to get user: %v", err)
+//	}
+//	log.Printf("Me: %v", me)
+func (c *Client) GetUser(ctx context.Context) (*users.UserOutput, error) {
+	return c.users.GetUser(ctx)
+}
+
+// UpdateUser updates the profile of the authenticated user.
+//
+// Example usage:
+//
+//	me, err := selfClient.UpdateUser(context.TODO(), &users.UpdateUserInput{
+//	    FirstName: "John",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update user: %v", err)
+//	}
+//	log.Printf("Updated Me: %v", me)
+func (c *Client) UpdateUser(ctx context.Context, input *users.UpdateUserInput) (*users.UserOutput, error) {
+	return c.users.UpdateUser(ctx, input)
+}
+
+// ChangePassword changes the password of the authenticated user.
+// The new password and its confirmation are checked to match before the request is made.
+//
+// Example usage:
+//
+//	err := selfClient.ChangePassword(context.TODO(), &users.ChangePasswordInput{
+//	    CurrentPassword: "oldpassword",
+//	    NewPassword:     "newpassword",
+//	    ConfirmPassword: "newpassword",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to change password: %v", err)
+//	}
+func (c *Client) ChangePassword(ctx context.Context, input *users.ChangePasswordInput) error {
+	if input == nil || input.CurrentPassword == "" {
+		return fmt.Errorf("current password is required")
+	}
+	if input.NewPassword != input.ConfirmPassword {
+		return fmt.Errorf("new password and confirmation do not match")
+	}
+	return c.users.ChangePassword(ctx, input)
+}
+
+// ListUserAPIKeys lists the API keys owned by the authenticated user.
+//
+// Example usage:
+//
+//	keys, err := selfClient.ListUserAPIKeys(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to list API keys: %v", err)
+//	}
+//	log.Printf("API Keys: %v", keys)
+func (c *Client) ListUserAPIKeys(ctx context.Context) ([]APIKey, error) {
+	apiResponse, err := generic.Get[generic.Response[[]APIKey]](ctx, c.config, "/user/api-keys", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return apiResponse.Data, nil
+}
+
+// RevokeAPIKey revokes one of the authenticated user's API keys.
+// The key is first looked up among the caller's own keys, so a key belonging
+// to another user is rejected without being sent to the API.
+//
+// Example usage:
+//
+//	err := selfClient.RevokeAPIKey(context.TODO(), "key-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to revoke API key: %v", err)
+//	}
+func (c *Client) RevokeAPIKey(ctx context.Context, keyID string) error {
+	if keyID == "" {
+		return fmt.Errorf("API key ID is required")
+	}
+
+	keys, err := c.ListUserAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	owned := false
+	for _, key := range keys {
+		if key.ID == keyID {
+			owned = true
+			break
+		}
+	}
+	if !owned {
+		return fmt.Errorf("API key %q does not belong to the authenticated user", keyID)
+	}
+
+	if err := generic.Delete(ctx, c.config, "/user/api-keys/"+url.PathEscape(keyID)); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return nil
+}
+
+// ListSessions lists the active login sessions of the authenticated user.
+//
+// Example usage:
+//
+//	sessions, err := selfClient.ListSessions(context.TODO())
+//	if err != nil {
+//	    log.Fatalf("Failed to list sessions: %v", err)
+//	}
+//	log.Printf("Sessions: %v", sessions)
+func (c *Client) ListSessions(ctx context.Context) ([]Session, error) {
+	apiResponse, err := generic.Get[generic.Response[[]Session]](ctx, c.config, "/user/sessions", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return apiResponse.Data, nil
+}
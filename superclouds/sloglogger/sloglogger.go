@@ -0,0 +1,65 @@
+// Package sloglogger adapts log/slog to the SDK's Logger interface.
+package sloglogger
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"log/slog"
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns a superclouds.Logger that writes to h.
+// The key-value pairs of each message are forwarded as slog attributes.
+//
+// Example usage:
+//
+//	logger := sloglogger.NewSlogLogger(slog.NewJSONHandler(os.Stderr, nil))
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithLogger(logger),
+//	)
+func NewSlogLogger(h slog.Handler) superclouds.Logger {
+	return &slogLogger{logger: slog.New(h)}
+}
+
+// Info implements superclouds.Logger.
+func (l *slogLogger) Info(msg string, kvs ...interface{}) {
+	l.logger.LogAttrs(context.Background(), slog.LevelInfo, msg, attrs(kvs)...)
+}
+
+// Debug implements superclouds.Logger.
+func (l *slogLogger) Debug(msg string, kvs ...interface{}) {
+	l.logger.LogAttrs(context.Background(), slog.LevelDebug, msg, attrs(kvs)...)
+}
+
+// Warn implements superclouds.Logger.
+func (l *slogLogger) Warn(msg string, kvs ...interface{}) {
+	l.logger.LogAttrs(context.Background(), slog.LevelWarn, msg, attrs(kvs)...)
+}
+
+// Error implements superclouds.Logger.
+func (l *slogLogger) Error(msg string, kvs ...interface{}) {
+	l.logger.LogAttrs(context.Background(), slog.LevelError, msg, attrs(kvs)...)
+}
+
+// attrs converts alternating keys and values to attributes.
+// A trailing key without a value is kept under the key "!BADKEY", as slog does.
+func attrs(kvs []interface{}) []slog.Attr {
+	out := make([]slog.Attr, 0, (len(kvs)+1)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		if i+1 == len(kvs) {
+			out = append(out, slog.Any("!BADKEY", kvs[i]))
+			break
+		}
+		key, ok := kvs[i].(string)
+		if !ok {
+			key = fmt.Sprint(kvs[i])
+		}
+		out = append(out, slog.Any(key, kvs[i+1]))
+	}
+	return out
+}
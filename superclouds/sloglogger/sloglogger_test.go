@@ -0,0 +1,45 @@
+package sloglogger_test
+
+import (
+	"bytes"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/sloglogger"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLoggerForwardsFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := sloglogger.NewSlogLogger(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	logger.Info("received response", "request_id", "req-123", "status", 200)
+	logger.Debug("sending request", "method", "GET")
+	logger.Warn("removed duplicate users from page", "removed", 2)
+	logger.Error("request failed", "error", "timeout")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("logged %d lines, want 4:\n%s", len(lines), buf.String())
+	}
+	for i, want := range []string{
+		`level=INFO msg="received response" request_id=req-123 status=200`,
+		`level=DEBUG msg="sending request" method=GET`,
+		`level=WARN msg="removed duplicate users from page" removed=2`,
+		`level=ERROR msg="request failed" error=timeout`,
+	} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("line %d = %q, want it to contain %q", i, lines[i], want)
+		}
+	}
+}
+
+func TestSlogLoggerOddKeyValues(t *testing.T) {
+	var buf bytes.Buffer
+	logger := sloglogger.NewSlogLogger(slog.NewTextHandler(&buf, nil))
+
+	logger.Info("message", "request_id")
+
+	if !strings.Contains(buf.String(), "!BADKEY=request_id") {
+		t.Errorf("output = %q, want the trailing key under !BADKEY", buf.String())
+	}
+}
@@ -0,0 +1,50 @@
+//go:build test
+
+package supertest
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"testing"
+)
+
+// FakeToken is the bearer token of the Configs created by NewFakeConfig.
+const FakeToken = "supertest-token"
+
+// NewFakeConfig creates a Config that sends its requests to serverURL, such
+// as the URL of a TestServer, over plain HTTP. No client certificate is
+// loaded, so no certificate files are needed; the token is FakeToken.
+// Requests are not retried after an HTTP 429 response, so that each stubbed
+// response answers exactly one call. The test fails if the Config cannot
+// be created.
+//
+// NewFakeConfig is only built with the test build tag: go test -tags test.
+//
+// Parameters:
+// - t: The test the Config belongs to.
+// - serverURL: The base URL of the stub API.
+//
+// Returns:
+// - Config: The Config to create clients with.
+//
+// Example usage:
+//
+//	server := supertest.NewServer(t)
+//	server.Expect(http.MethodGet, "/user").Return(http.StatusOK, map[string]interface{}{
+//	    "data": users.User{Id: "1", Email: "jane@example.com"},
+//	})
+//	usersClient := users.NewUsersClient(supertest.NewFakeConfig(t, server.URL))
+//	output, err := usersClient.GetUser(context.TODO())
+func NewFakeConfig(t testing.TB, serverURL string) *superclouds.Config {
+	t.Helper()
+	cfg, err := superclouds.NewConfigWithOptions("", "",
+		superclouds.WithBaseURL(serverURL),
+		superclouds.WithToken(FakeToken),
+		superclouds.WithHTTPClient(&http.Client{}),
+		superclouds.WithRetryConfig(superclouds.RetryConfig{}),
+	)
+	if err != nil {
+		t.Fatalf("supertest: failed to create config: %v", err)
+	}
+	return cfg
+}
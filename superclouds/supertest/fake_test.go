@@ -0,0 +1,41 @@
+//go:build test
+
+package supertest_test
+
+import (
+	"context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/supertest"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/http"
+	"testing"
+)
+
+func TestFakeConfigGetUser(t *testing.T) {
+	server := supertest.NewServer(t)
+	server.Expect(http.MethodGet, "/user").Return(http.StatusOK, map[string]interface{}{
+		"data": users.User{Id: "1", Email: "jane@example.com"},
+	})
+
+	usersClient := users.NewUsersClient(supertest.NewFakeConfig(t, server.URL))
+	output, err := usersClient.GetUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if output.Id != "1" || output.Email != "jane@example.com" {
+		t.Errorf("GetUser = %+v, want user 1 jane@example.com", output.User)
+	}
+	server.AssertCalled(t, http.MethodGet, "/user")
+}
+
+func TestFakeConfigDoesNotRetry(t *testing.T) {
+	server := supertest.NewServer(t)
+	server.Expect(http.MethodGet, "/user").Return(http.StatusTooManyRequests, nil)
+
+	usersClient := users.NewUsersClient(supertest.NewFakeConfig(t, server.URL))
+	if _, err := usersClient.GetUser(context.Background()); err == nil {
+		t.Fatalf("GetUser after a 429 response returned no error")
+	}
+	if calls := len(server.Calls()); calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,166 @@
+// Package supertest provides a stub Superclouds API server for testing code
+// that uses the SDK. Tests declare the responses of the endpoints they call
+// with Expect, point the SDK at the server's URL, and then inspect the calls
+// the server received.
+package supertest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// RecordedCall is a request received by a TestServer.
+type RecordedCall struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Expectation is the stubbed response of an endpoint, see TestServer.Expect.
+type Expectation struct {
+	method string
+	path   string
+
+	mu         sync.Mutex
+	statusCode int
+	body       []byte
+}
+
+// Return sets the response of the endpoint. body is written as is when it
+// is a []byte or a string, omitted when it is nil, and encoded to JSON
+// otherwise. It returns the Expectation for chaining.
+//
+// Example usage:
+//
+//	server.Expect(http.MethodGet, "/users").Return(http.StatusOK, map[string]interface{}{
+//	    "data": []users.User{{Id: "1", Email: "jane@example.com"}},
+//	})
+func (e *Expectation) Return(statusCode int, body interface{}) *Expectation {
+	var b []byte
+	switch v := body.(type) {
+	case nil:
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			panic("supertest: failed to encode response body: " + err.Error())
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.statusCode = statusCode
+	e.body = b
+	return e
+}
+
+// TestServer is a stub Superclouds API server. Requests to endpoints without
+// an expectation fail the test and receive a 404 response.
+type TestServer struct {
+	*httptest.Server
+
+	t  testing.TB
+	mu sync.Mutex
+	// expectations are matched from the last declared to the first.
+	expectations []*Expectation
+	calls        []RecordedCall
+}
+
+// NewServer starts a TestServer, which is closed when the test ends.
+//
+// Parameters:
+// - t: The test the server belongs to.
+//
+// Returns:
+// - TestServer: The running server; its URL is the base URL of the stub API.
+//
+// Example usage:
+//
+//	server := supertest.NewServer(t)
+//	server.Expect(http.MethodDelete, "/users").Return(http.StatusNoContent, nil)
+//	cfg.SuperURL = server.URL
+func NewServer(t testing.TB) *TestServer {
+	s := &TestServer{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Expect declares an expectation for requests with method to path, such as
+// "/users", which responds with 200 OK and no body until Return is called.
+// A later expectation for the same endpoint replaces an earlier one.
+func (s *TestServer) Expect(method, path string) *Expectation {
+	e := &Expectation{method: method, path: path, statusCode: http.StatusOK}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expectations = append(s.expectations, e)
+	return e
+}
+
+// Calls returns the requests received by the server, in order.
+func (s *TestServer) Calls() []RecordedCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedCall(nil), s.calls...)
+}
+
+// AssertCalled fails t unless the server received a request with method to path.
+func (s *TestServer) AssertCalled(t testing.TB, method, path string) {
+	t.Helper()
+	for _, call := range s.Calls() {
+		if call.Method == method && call.Path == path {
+			return
+		}
+	}
+	t.Errorf("supertest: expected a call to %s %s", method, path)
+}
+
+// serveHTTP records the request and writes the response of its expectation.
+func (s *TestServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.t.Errorf("supertest: failed to read request body of %s %s: %v", r.Method, r.URL.Path, err)
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, RecordedCall{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	var match *Expectation
+	for i := len(s.expectations) - 1; i >= 0; i-- {
+		if e := s.expectations[i]; e.method == r.Method && e.path == r.URL.Path {
+			match = e
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if match == nil {
+		s.t.Errorf("supertest: unexpected call to %s %s", r.Method, r.URL.Path)
+		http.Error(w, `{"message":"no expectation for this endpoint"}`, http.StatusNotFound)
+		return
+	}
+
+	match.mu.Lock()
+	statusCode, respBody := match.statusCode, match.body
+	match.mu.Unlock()
+
+	if len(respBody) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(statusCode)
+	w.Write(respBody)
+}
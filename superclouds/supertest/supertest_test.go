@@ -0,0 +1,96 @@
+package supertest_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/supertest"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/http"
+	"testing"
+)
+
+// exampleT stands in for the *testing.T of a test in the examples: it
+// prints failures and runs the cleanup functions when done is called.
+type exampleT struct {
+	testing.TB
+	cleanups []func()
+}
+
+func (t *exampleT) Helper() {}
+
+func (t *exampleT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *exampleT) Errorf(format string, args ...interface{}) {
+	fmt.Printf("FAIL: "+format+"\n", args...)
+}
+
+func (t *exampleT) Fatalf(format string, args ...interface{}) {
+	panic(fmt.Sprintf(format, args...))
+}
+
+func (t *exampleT) done() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+// newUsersClient returns a UsersClient that sends its requests to server.
+func newUsersClient(t testing.TB, server *supertest.TestServer) *users.UsersClient {
+	cfg, err := superclouds.NewConfigWithOptions("", "",
+		superclouds.WithBaseURL(server.URL),
+		superclouds.WithToken("test-token"),
+		superclouds.WithHTTPClient(server.Client()),
+		// A fixed request ID keeps the errors printed by the examples stable.
+		superclouds.WithRequestIDGenerator(func() string { return "request-1" }),
+	)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	return users.NewUsersClient(cfg)
+}
+
+func ExampleTestServer() {
+	t := &exampleT{}
+	defer t.done()
+
+	server := supertest.NewServer(t)
+	server.Expect(http.MethodGet, "/users").Return(http.StatusOK, map[string]interface{}{
+		"data": []users.User{
+			{Id: "1", Email: "jane@example.com"},
+			{Id: "2", Email: "john@example.com"},
+		},
+		"page":  1,
+		"pages": 1,
+	})
+
+	output, err := newUsersClient(t, server).ListUsers(context.Background(), &users.ListUsersInput{SearchTerm: "example.com"})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	for _, user := range output.Users {
+		fmt.Println(user.Id, user.Email)
+	}
+
+	server.AssertCalled(t, http.MethodGet, "/users")
+	fmt.Println(server.Calls()[0].Query.Get("s"))
+	// Output:
+	// 1 jane@example.com
+	// 2 john@example.com
+	// example.com
+}
+
+func ExampleExpectation_Return() {
+	t := &exampleT{}
+	defer t.done()
+
+	server := supertest.NewServer(t)
+	server.Expect(http.MethodGet, "/users").Return(http.StatusInternalServerError, `{"message":"database unavailable"}`)
+
+	_, err := newUsersClient(t, server).ListUsers(context.Background(), &users.ListUsersInput{})
+	fmt.Println(err)
+	// Output:
+	// failed to list users: unexpected status: 500 Internal Server Error: database unavailable (request ID request-1)
+}
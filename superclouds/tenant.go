@@ -0,0 +1,47 @@
+package superclouds
+
+import (
+	"context"
+	"net/http"
+)
+
+// tenantIDHeader routes a request to the tenant it is made on behalf of.
+const tenantIDHeader = "X-Tenant-ID"
+
+type tenantIDKey struct{}
+
+// WithTenantID returns a copy of ctx that carries the tenant ID id. Requests
+// made with the context are sent with an X-Tenant-ID header, so that a single
+// client can serve several tenants.
+//
+// Example usage:
+//
+//	ctx := superclouds.WithTenantID(context.TODO(), "tenant-42")
+//	output, err := usersClient.ListUsers(ctx, &users.ListUsersInput{})
+func WithTenantID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, id)
+}
+
+// TenantIDFrom returns the tenant ID stored in ctx with WithTenantID, or "".
+func TenantIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(tenantIDKey{}).(string)
+	return id
+}
+
+// tenantRoundTripper wraps a RoundTripper and sets the tenant ID header from the request context.
+type tenantRoundTripper struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tenantRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := TenantIDFrom(req.Context())
+	if id == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	req.Header.Set(tenantIDHeader, id)
+	return t.next.RoundTrip(req)
+}
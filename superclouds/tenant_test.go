@@ -0,0 +1,62 @@
+package superclouds_test
+
+import (
+	"context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTenantIDPerRequest(t *testing.T) {
+	// The server checks that each request carries the tenant ID named in its path.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := strings.TrimPrefix(r.URL.Path, "/tenants/")
+		if got := r.Header.Get("X-Tenant-ID"); got != want {
+			t.Errorf("X-Tenant-ID = %q, want %q", got, want)
+		}
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv)
+
+	var wg sync.WaitGroup
+	for _, tenant := range []string{"tenant-a", "tenant-b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := superclouds.WithTenantID(context.Background(), tenant)
+			for i := 0; i < 20; i++ {
+				req, err := cfg.NewRequest(ctx, http.MethodGet, cfg.URL("/tenants/"+tenant), nil)
+				if err != nil {
+					t.Errorf("NewRequest: %v", err)
+					return
+				}
+				resp, err := cfg.Client.Do(req)
+				if err != nil {
+					t.Errorf("request failed: %v", err)
+					return
+				}
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNoTenantIDHeaderWithoutTenant(t *testing.T) {
+	var header []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Values("X-Tenant-ID")
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv)
+
+	if _, err := get(t, cfg, "/users/me"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if len(header) != 0 {
+		t.Errorf("X-Tenant-ID = %q, want no header", header)
+	}
+}
@@ -0,0 +1,45 @@
+package superclouds
+
+import (
+	"context"
+)
+
+// TokenProvider supplies the bearer token for API authorization.
+// It allows tokens to be fetched dynamically, for example from a cloud
+// workload identity, instead of being fixed when the Config is created.
+type TokenProvider interface {
+	// Token returns a valid bearer token.
+	Token(ctx context.Context) (string, error)
+}
+
+type staticTokenProvider string
+
+func (p staticTokenProvider) Token(context.Context) (string, error) {
+	return string(p), nil
+}
+
+// StaticTokenProvider returns a TokenProvider that always returns token.
+//
+// Example usage:
+//
+//	provider := superclouds.StaticTokenProvider(superToken)
+func StaticTokenProvider(token string) TokenProvider {
+	return staticTokenProvider(token)
+}
+
+// WithTokenProvider sets the TokenProvider used to authorize requests.
+// The transport asks the provider for a token before the first request and
+// reuses it for subsequent requests; the token takes precedence over SuperToken.
+// When the API answers 401 Unauthorized, the token is refreshed from the
+// provider and the request is replayed once.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithTokenProvider(workloadIdentityProvider),
+//	)
+func WithTokenProvider(provider TokenProvider) Option {
+	return func(c *Config) {
+		c.tokenProvider = provider
+	}
+}
@@ -0,0 +1,76 @@
+package superclouds_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingTokenProvider returns "token-1", "token-2" and so on, one per call.
+type countingTokenProvider struct {
+	calls atomic.Int32
+}
+
+func (p *countingTokenProvider) Token(context.Context) (string, error) {
+	return fmt.Sprintf("token-%d", p.calls.Add(1)), nil
+}
+
+func TestTokenRefreshAfterUnauthorized(t *testing.T) {
+	var authorizations []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if len(authorizations) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	provider := &countingTokenProvider{}
+	cfg := newTestConfig(t, srv, superclouds.WithTokenProvider(provider))
+
+	status, err := get(t, cfg, "/users/me")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Errorf("status = %d, want %d", status, http.StatusOK)
+	}
+	want := []string{"Bearer token-1", "Bearer token-2"}
+	if fmt.Sprint(authorizations) != fmt.Sprint(want) {
+		t.Errorf("Authorization headers = %q, want %q", authorizations, want)
+	}
+}
+
+func TestTokenRefreshOnceForConcurrentRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	provider := &countingTokenProvider{}
+	cfg := newTestConfig(t, srv, superclouds.WithTokenProvider(provider))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := get(t, cfg, "/users/me")
+			if err != nil || status != http.StatusOK {
+				t.Errorf("request = %d, %v, want %d", status, err, http.StatusOK)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls := provider.calls.Load(); calls != 2 {
+		t.Errorf("provider calls = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,76 @@
+package superclouds
+
+import (
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// requestIDHeader is the header the API uses to identify a request in its logs.
+const requestIDHeader = "X-Request-ID"
+
+// WithTracer records an OpenTelemetry span for every request made with the Config.
+// Spans are named superclouds.{resource}.{method}, for example
+// superclouds.users.list, and carry the http.method, http.url,
+// http.status_code and superclouds.request_id attributes. The W3C
+// traceparent and tracestate headers are injected into each request so that
+// the trace continues on the server.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithTracer(otel.Tracer("my-service")),
+//	)
+func WithTracer(t trace.Tracer) Option {
+	return func(c *Config) {
+		c.tracer = t
+	}
+}
+
+// tracingRoundTripper wraps a RoundTripper with a span per request.
+type tracingRoundTripper struct {
+	next    http.RoundTripper
+	tracer  trace.Tracer
+	baseURL string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tracingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource, method := operationOf(req, t.baseURL)
+	ctx, span := t.tracer.Start(req.Context(), fmt.Sprintf("superclouds.%s.%s", resource, method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.url", req.URL.Redacted()),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	requestID := resp.Header.Get(requestIDHeader)
+	if requestID == "" {
+		requestID = req.Header.Get(requestIDHeader)
+	}
+	if requestID != "" {
+		span.SetAttributes(attribute.String("superclouds.request_id", requestID))
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
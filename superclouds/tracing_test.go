@@ -0,0 +1,66 @@
+package superclouds_test
+
+import (
+	"context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracerRecordsSpanAndInjectsTraceparent(t *testing.T) {
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("X-Request-ID", "req-123")
+	}))
+	defer srv.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	cfg := newTestConfig(t, srv, superclouds.WithTracer(provider.Tracer("test")))
+
+	ctx := superclouds.WithOperation(context.Background(), "users", "list")
+	req, err := cfg.NewRequest(ctx, http.MethodGet, cfg.URL("/users"), nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "superclouds.users.list" {
+		t.Errorf("span name = %q, want %q", span.Name(), "superclouds.users.list")
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if got := attrs["http.method"].AsString(); got != http.MethodGet {
+		t.Errorf("http.method = %q, want %q", got, http.MethodGet)
+	}
+	if got := attrs["http.status_code"].AsInt64(); got != http.StatusOK {
+		t.Errorf("http.status_code = %d, want %d", got, http.StatusOK)
+	}
+	if got := attrs["superclouds.request_id"].AsString(); got != "req-123" {
+		t.Errorf("superclouds.request_id = %q, want %q", got, "req-123")
+	}
+
+	if traceparent == "" {
+		t.Fatalf("traceparent header not injected")
+	}
+	if want := span.SpanContext().TraceID().String(); len(traceparent) < 35 || traceparent[3:35] != want {
+		t.Errorf("traceparent = %q, want trace ID %s", traceparent, want)
+	}
+}
@@ -0,0 +1,47 @@
+// Package transform maps SDK types to the caller's own domain models.
+package transform
+
+import (
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+)
+
+// UserMapper converts users.User values to a caller-defined type T with a caller-supplied function.
+// It is a convenience wrapper, not a performance optimisation.
+//
+// Example usage:
+//
+//	mapper := transform.NewUserMapper(func(u *users.User) (Member, error) {
+//	    return Member{ID: u.Id, Email: u.Email}, nil
+//	})
+//	members, err := mapper.MapAll(userList)
+//	if err != nil {
+//	    log.Fatalf("Failed to map users: %v", err)
+//	}
+type UserMapper[T any] struct {
+	fn func(*users.User) (T, error)
+}
+
+// NewUserMapper creates a UserMapper that applies fn to each user.
+func NewUserMapper[T any](fn func(*users.User) (T, error)) *UserMapper[T] {
+	return &UserMapper[T]{fn: fn}
+}
+
+// Map converts a single user.
+func (m *UserMapper[T]) Map(u *users.User) (T, error) {
+	return m.fn(u)
+}
+
+// MapAll converts every user in order and stops at the first error,
+// which is returned together with the index of the failing user.
+func (m *UserMapper[T]) MapAll(us []*users.User) ([]T, error) {
+	out := make([]T, 0, len(us))
+	for i, u := range us {
+		v, err := m.fn(u)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map user %d: %w", i, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
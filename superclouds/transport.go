@@ -0,0 +1,258 @@
+package superclouds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// transport is the http.RoundTripper installed on every Config's client.
+// It applies the cross-cutting behaviour configured through options to each
+// request before handing it to the base transport.
+type transport struct {
+	base           http.RoundTripper
+	logger         Logger
+	tokenProvider  TokenProvider
+	requestTimeout time.Duration
+	retryConfig    RetryConfig
+	// generateRequestID, when set, generates the X-Request-ID header of requests that have none.
+	generateRequestID func() string
+
+	mu    sync.Mutex
+	token string
+}
+
+func newTransport(cfg *Config, base http.RoundTripper) *transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if cfg.debugWriter != nil {
+		maxBodyBytes := cfg.MaxDebugBodyBytes
+		if maxBodyBytes <= 0 {
+			maxBodyBytes = defaultMaxDebugBodyBytes
+		}
+		base = &debugRoundTripper{next: base, maxBodyBytes: maxBodyBytes, filter: cfg.debugBodyFilter, redactIdentity: cfg.debugRedactIdentity, w: cfg.debugWriter}
+	}
+	if cfg.metrics != nil {
+		base = &metricsRoundTripper{next: base, metrics: cfg.metrics, baseURL: cfg.URL("")}
+	}
+	if cfg.tracer != nil {
+		base = &tracingRoundTripper{next: base, tracer: cfg.tracer, baseURL: cfg.URL("")}
+	}
+	if cfg.rateLimiter != nil {
+		base = &rateLimitRoundTripper{next: base, limiter: cfg.rateLimiter}
+	}
+	if cfg.CircuitBreaker.ConsecutiveFailuresThreshold > 0 {
+		base = newBreakerRoundTripper(base, cfg.CircuitBreaker, cfg.Logger())
+	}
+	base = &tenantRoundTripper{next: base}
+	if len(cfg.staticHeaders) > 0 {
+		base = &staticHeadersRoundTripper{next: base, headers: cfg.staticHeaders}
+	}
+	return &transport{
+		base:           base,
+		logger:         cfg.Logger(),
+		tokenProvider:  cfg.tokenProvider,
+		requestTimeout: cfg.RequestTimeout,
+		retryConfig:    cfg.retryConfig,
+
+		generateRequestID: cfg.requestIDGenerator,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Deadlines set by the caller, shorter or longer, take precedence over the request timeout.
+	ctx, cancel := req.Context(), context.CancelFunc(nil)
+	if _, ok := ctx.Deadline(); !ok && t.requestTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.requestTimeout)
+	}
+
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(ctx)
+
+	// The SDK identifies itself after any User-Agent set by the caller.
+	if ua := req.Header.Get("User-Agent"); ua != "" {
+		req.Header.Set("User-Agent", ua+" "+userAgent())
+	} else {
+		req.Header.Set("User-Agent", userAgent())
+	}
+
+	// The ID is set once, before the token refresh and rate limit retries, which all reuse it.
+	if t.generateRequestID != nil && req.Header.Get(requestIDHeader) == "" {
+		req.Header.Set(requestIDHeader, t.generateRequestID())
+	}
+
+	// Only the path is logged: query strings may carry personal data such as emails.
+	t.logger.Debug("sending request", "method", req.Method, "path", req.URL.Path)
+	resp, err := t.send(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		resp, err = t.retryRateLimited(req, resp)
+	}
+	if err != nil {
+		t.logger.Error("request failed", "method", req.Method, "path", req.URL.Path, "error", err)
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
+	t.logger.Debug("received response", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode)
+
+	// The timeout also covers reading the body, so it is released when the body is closed.
+	if cancel != nil {
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	}
+
+	return resp, nil
+}
+
+// send authorizes req with the provider token, if any, and sends it.
+func (t *transport) send(req *http.Request) (*http.Response, error) {
+	if t.tokenProvider == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := t.cachedToken(req)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The token has most likely expired: refresh it and replay the request once.
+	retry, ok := rewind(req)
+	if !ok {
+		return resp, nil
+	}
+
+	t.logger.Info("retrying request after 401 with a refreshed token", "method", req.Method, "path", req.URL.Path)
+	token, err = t.refreshToken(req, token)
+	if err != nil {
+		t.logger.Error("token refresh failed", "error", err)
+		return resp, nil
+	}
+	drainAndClose(resp.Body)
+	retry.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(retry)
+}
+
+// rewind returns a copy of req that can be sent again, with a fresh body.
+// It returns false when the body of req cannot be rewound.
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body != nil && req.GetBody == nil {
+		return nil, false
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, false
+		}
+		retry.Body = body
+	}
+	return retry, true
+}
+
+// cachedToken returns the last token obtained from the provider, fetching one if there is none yet.
+func (t *transport) cachedToken(req *http.Request) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == "" {
+		token, err := t.tokenProvider.Token(req.Context())
+		if err != nil {
+			return "", fmt.Errorf("failed to get token: %w", err)
+		}
+		t.token = token
+	}
+	return t.token, nil
+}
+
+// refreshToken replaces the stale token with a new one from the provider.
+// When several requests fail with the same stale token at once, only the
+// first one calls the provider and the others reuse its result.
+func (t *transport) refreshToken(req *http.Request, stale string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != stale {
+		return t.token, nil
+	}
+
+	token, err := t.tokenProvider.Token(req.Context())
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh token: %w", err)
+	}
+	t.token = token
+	t.logger.Info("token refreshed")
+	return token, nil
+}
+
+// drainAndClose discards what is left of a response body, up to a limit, and
+// closes it so that the underlying connection can be reused.
+func drainAndClose(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, 4096))
+	body.Close()
+}
+
+// WithMiddleware wraps the SDK's transport with middleware. Middlewares see
+// each call before the SDK's own handling, such as the request timeout, the
+// retries and the token; the one given last is the outermost.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+//	        return correlation.NewRoundTripper(nil, next)
+//	    }),
+//	)
+func WithMiddleware(middleware func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *Config) {
+		c.middlewares = append(c.middlewares, middleware)
+	}
+}
+
+// cancelOnClose is a response body that cancels the request context when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// installTransport wraps the transport of cfg.Client, see WrapHTTPClient.
+func installTransport(cfg *Config) {
+	cfg.Client = cfg.WrapHTTPClient(cfg.Client)
+}
+
+// WrapHTTPClient returns a copy of client whose transport applies the
+// settings of the Config, such as the request timeout, retries and
+// middlewares, as NewConfigWithOptions does for the client it sets up.
+// client itself is left unchanged.
+//
+// Example usage:
+//
+//	client := cfg.WrapHTTPClient(&http.Client{Transport: proxyTransport})
+func (c *Config) WrapHTTPClient(client *http.Client) *http.Client {
+	wrapped := *client
+	var rt http.RoundTripper = newTransport(c, wrapped.Transport)
+	for _, middleware := range c.middlewares {
+		rt = middleware(rt)
+	}
+	wrapped.Transport = rt
+	return &wrapped
+}
@@ -0,0 +1,122 @@
+package superclouds
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportOptions tunes the http.Transport that the SDK builds for a Config.
+// Zero fields keep the Go defaults.
+type TransportOptions struct {
+	// MaxIdleConns and MaxIdleConnsPerHost size the pool of idle connections
+	// kept for reuse, and IdleConnTimeout is how long a connection may stay idle in it.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	ExpectContinueTimeout time.Duration
+}
+
+// WithTransportOptions applies opts to the underlying http.Transport at construction time.
+// It has no effect when the HTTP client is supplied with WithHTTPClient.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithTransportOptions(superclouds.TransportOptions{
+//	        MaxIdleConns:          200,
+//	        MaxIdleConnsPerHost:   100,
+//	        IdleConnTimeout:       90 * time.Second,
+//	        DialTimeout:           5 * time.Second,
+//	        ResponseHeaderTimeout: 10 * time.Second,
+//	    }),
+//	)
+func WithTransportOptions(opts TransportOptions) Option {
+	return func(c *Config) {
+		c.transportOptions = opts
+	}
+}
+
+// WithHTTP2 enables HTTP/2 on the underlying http.Transport, so that
+// concurrent requests are multiplexed over a single connection when the
+// server supports it. It has no effect when the HTTP client is supplied with
+// WithHTTPClient.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithHTTP2(),
+//	)
+func WithHTTP2() Option {
+	return func(c *Config) {
+		c.http2 = true
+	}
+}
+
+// WithProxy routes the SDK's requests through the proxy at proxyURL, whose
+// scheme must be http, https or socks5. NewConfigWithOptions returns an error
+// if proxyURL is invalid. It has no effect when the HTTP client is supplied
+// with WithHTTPClient.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithProxy("socks5://proxy.internal:1080"),
+//	)
+func WithProxy(proxyURL string) Option {
+	return func(c *Config) {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			c.setOptionErr(fmt.Errorf("invalid proxy URL: %w", err))
+			return
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			c.setOptionErr(fmt.Errorf("invalid proxy URL: unsupported scheme %q", u.Scheme))
+			return
+		}
+		if u.Host == "" {
+			c.setOptionErr(fmt.Errorf("invalid proxy URL: missing host"))
+			return
+		}
+		c.proxyURL = u
+	}
+}
+
+// apply sets the non-zero options on t.
+func (o TransportOptions) apply(t *http.Transport) {
+	if o.DialTimeout > 0 {
+		t.DialContext = (&net.Dialer{
+			Timeout:   o.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	if o.TLSHandshakeTimeout > 0 {
+		t.TLSHandshakeTimeout = o.TLSHandshakeTimeout
+	}
+	if o.ResponseHeaderTimeout > 0 {
+		t.ResponseHeaderTimeout = o.ResponseHeaderTimeout
+	}
+	if o.ExpectContinueTimeout > 0 {
+		t.ExpectContinueTimeout = o.ExpectContinueTimeout
+	}
+	if o.MaxIdleConns > 0 {
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = o.IdleConnTimeout
+	}
+}
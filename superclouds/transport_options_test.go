@@ -0,0 +1,163 @@
+package superclouds
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// httpTransport returns the http.Transport built by setupClient under the round trippers of the SDK.
+func httpTransport(t *testing.T, cfg *Config) *http.Transport {
+	t.Helper()
+	rt := cfg.Client.Transport
+	for {
+		switch r := rt.(type) {
+		case *http.Transport:
+			return r
+		case *transport:
+			rt = r.base
+		case *tenantRoundTripper:
+			rt = r.next
+		default:
+			t.Fatalf("unexpected round tripper %T", rt)
+		}
+	}
+}
+
+func TestWithTransportOptions(t *testing.T) {
+	cfg, err := NewConfigWithOptions("", "",
+		WithToken("test-token"),
+		WithTransportOptions(TransportOptions{
+			MaxIdleConns:          200,
+			MaxIdleConnsPerHost:   100,
+			IdleConnTimeout:       90 * time.Second,
+			DialTimeout:           5 * time.Second,
+			TLSHandshakeTimeout:   6 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			ExpectContinueTimeout: 2 * time.Second,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	tr := httpTransport(t, cfg)
+	if tr.MaxIdleConns != 200 {
+		t.Errorf("MaxIdleConns = %d, want 200", tr.MaxIdleConns)
+	}
+	if tr.MaxIdleConnsPerHost != 100 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 100", tr.MaxIdleConnsPerHost)
+	}
+	if tr.IdleConnTimeout != 90*time.Second {
+		t.Errorf("IdleConnTimeout = %v, want 90s", tr.IdleConnTimeout)
+	}
+	if tr.TLSHandshakeTimeout != 6*time.Second {
+		t.Errorf("TLSHandshakeTimeout = %v, want 6s", tr.TLSHandshakeTimeout)
+	}
+	if tr.ResponseHeaderTimeout != 10*time.Second {
+		t.Errorf("ResponseHeaderTimeout = %v, want 10s", tr.ResponseHeaderTimeout)
+	}
+	if tr.ExpectContinueTimeout != 2*time.Second {
+		t.Errorf("ExpectContinueTimeout = %v, want 2s", tr.ExpectContinueTimeout)
+	}
+	if tr.DialContext == nil {
+		t.Errorf("DialContext is nil, want a dialer with the DialTimeout")
+	}
+}
+
+func TestWithTransportOptionsZeroKeepsDefaults(t *testing.T) {
+	cfg, err := NewConfigWithOptions("", "", WithToken("test-token"))
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	tr := httpTransport(t, cfg)
+	if tr.MaxIdleConns != 0 || tr.IdleConnTimeout != 0 || tr.DialContext != nil {
+		t.Errorf("transport = %+v, want Go defaults", tr)
+	}
+}
+
+// protoOf returns the protocol of the response of srv to a request sent with a
+// Config that trusts srv, built with opts.
+func protoOf(t *testing.T, srv *httptest.Server, opts ...Option) string {
+	t.Helper()
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caPath, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA certificate: %v", err)
+	}
+
+	opts = append([]Option{WithBaseURL(srv.URL), WithToken("test-token"), WithCACert(caPath)}, opts...)
+	cfg, err := NewConfigWithOptions("", "", opts...)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	req, err := cfg.NewRequest(context.Background(), http.MethodGet, cfg.URL("/users/me"), nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	return resp.Proto
+}
+
+func TestWithHTTP2(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	if proto := protoOf(t, srv, WithHTTP2()); proto != "HTTP/2.0" {
+		t.Errorf("protocol with WithHTTP2 = %q, want %q", proto, "HTTP/2.0")
+	}
+	if proto := protoOf(t, srv); proto != "HTTP/1.1" {
+		t.Errorf("protocol without WithHTTP2 = %q, want %q", proto, "HTTP/1.1")
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A proxy receives the absolute URL of the target in the request line.
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+
+	cfg, err := NewConfigWithOptions("", "",
+		WithBaseURL("http://api.superclouds.example/v1"),
+		WithToken("test-token"),
+		WithProxy(proxy.URL),
+	)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	req, err := cfg.NewRequest(context.Background(), http.MethodGet, cfg.URL("/users/me"), nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		t.Fatalf("request through the proxy failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(proxied) != 1 || proxied[0] != "http://api.superclouds.example/v1/users/me" {
+		t.Errorf("proxied requests = %q, want the request to http://api.superclouds.example/v1/users/me", proxied)
+	}
+}
+
+func TestWithProxyInvalidURL(t *testing.T) {
+	for _, proxyURL := range []string{"://proxy", "ftp://proxy.internal:21"} {
+		if _, err := NewConfigWithOptions("", "", WithToken("test-token"), WithProxy(proxyURL)); err == nil {
+			t.Errorf("WithProxy(%q) returned no error", proxyURL)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package superclouds_test
+
+import (
+	"context"
+	"errors"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newSlowServer starts a server that answers after delay, or when the request is cancelled.
+func newSlowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRequestTimeout(t *testing.T) {
+	srv := newSlowServer(t, time.Second)
+	cfg := newTestConfig(t, srv, superclouds.WithRequestTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := get(t, cfg, "/users/me")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("request took %v, want the timeout of 50ms", elapsed)
+	}
+}
+
+func TestRequestTimeoutKeepsCallerDeadline(t *testing.T) {
+	srv := newSlowServer(t, 100*time.Millisecond)
+	cfg := newTestConfig(t, srv, superclouds.WithRequestTimeout(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, err := cfg.NewRequest(ctx, http.MethodGet, cfg.URL("/users/me"), nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		t.Fatalf("request with a longer caller deadline failed: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,39 @@
+package superclouds
+
+import (
+	"regexp"
+	"strings"
+)
+
+// versionSegment matches the version segment at the end of a base URL, such as /v1.
+var versionSegment = regexp.MustCompile(`/v[0-9]+$`)
+
+// WithAPIVersion sets the version of the API that requests are sent to, such as "v2".
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    superclouds.WithAPIVersion("v2"),
+//	)
+func WithAPIVersion(version string) Option {
+	return func(c *Config) {
+		c.APIVersion = version
+	}
+}
+
+// URL returns the URL of the API endpoint at path, such as "/users".
+// The version segment of SuperURL, such as /v1, is replaced by APIVersion.
+// A SuperURL without a version segment, such as that of a proxy that routes
+// versions itself, and an empty APIVersion leave SuperURL as it is.
+//
+// Example usage:
+//
+//	req, err := cfg.NewRequest(ctx, http.MethodGet, cfg.URL("/users"), nil)
+func (c *Config) URL(path string) string {
+	base := strings.TrimSuffix(c.SuperURL, "/")
+	if c.APIVersion != "" && versionSegment.MatchString(base) {
+		base = versionSegment.ReplaceAllLiteralString(base, "/"+c.APIVersion)
+	}
+	return base + path
+}
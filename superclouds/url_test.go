@@ -0,0 +1,46 @@
+package superclouds_test
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIVersionInRequestURL(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv,
+		superclouds.WithBaseURL(srv.URL+"/api/v1"),
+		superclouds.WithAPIVersion("v2"),
+	)
+
+	if _, err := get(t, cfg, "/users"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if path != "/api/v2/users" {
+		t.Errorf("request path = %q, want %q", path, "/api/v2/users")
+	}
+}
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		baseURL    string
+		apiVersion string
+		want       string
+	}{
+		{"https://api.example.com/api/v1", "v2", "https://api.example.com/api/v2/users"},
+		{"https://api.example.com/api/v1/", "v2", "https://api.example.com/api/v2/users"},
+		{"https://api.example.com/api/v1", "", "https://api.example.com/api/v1/users"},
+		{"https://proxy.example.com", "v2", "https://proxy.example.com/users"},
+	}
+	for _, tt := range tests {
+		cfg := &superclouds.Config{SuperURL: tt.baseURL, APIVersion: tt.apiVersion}
+		if got := cfg.URL("/users"); got != tt.want {
+			t.Errorf("URL with base %q and version %q = %q, want %q", tt.baseURL, tt.apiVersion, got, tt.want)
+		}
+	}
+}
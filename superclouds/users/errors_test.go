@@ -0,0 +1,246 @@
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// call makes one request with a UsersClient method and returns its error.
+type call struct {
+	name string
+	// decodes is true for the methods that decode a response body.
+	decodes bool
+	fn      func(ctx context.Context, c *users.UsersClient) error
+}
+
+var calls = []call{
+	{"ListUsers", true, func(ctx context.Context, c *users.UsersClient) error {
+		_, err := c.ListUsers(ctx, &users.ListUsersInput{})
+		return err
+	}},
+	{"CreateUser", true, func(ctx context.Context, c *users.UsersClient) error {
+		_, err := c.CreateUser(ctx, &users.CreateUserInput{Email: "new.user@example.com"})
+		return err
+	}},
+	{"DeleteUser", false, func(ctx context.Context, c *users.UsersClient) error {
+		return c.DeleteUser(ctx, &users.DeleteUserInput{Email: "delete.user@example.com"})
+	}},
+	{"UpdateUser", true, func(ctx context.Context, c *users.UsersClient) error {
+		_, err := c.UpdateUser(ctx, &users.UpdateUserInput{FirstName: "John"})
+		return err
+	}},
+	{"GetUser", true, func(ctx context.Context, c *users.UsersClient) error {
+		_, err := c.GetUser(ctx)
+		return err
+	}},
+	{"UpdateUserRole", false, func(ctx context.Context, c *users.UsersClient) error {
+		return c.UpdateUserRole(ctx, &users.UpdateUserRoleInput{Email: "user@example.com", Role: string(users.RoleModify)})
+	}},
+	{"ChangePassword", false, func(ctx context.Context, c *users.UsersClient) error {
+		return c.ChangePassword(ctx, &users.ChangePasswordInput{})
+	}},
+	{"MergeUsers", false, func(ctx context.Context, c *users.UsersClient) error {
+		return c.MergeUsers(ctx, "user-1", "user-2")
+	}},
+	{"GetUserTrustScore", true, func(ctx context.Context, c *users.UsersClient) error {
+		_, err := c.GetUserTrustScore(ctx, "user-1")
+		return err
+	}},
+	{"GetUserQuota", true, func(ctx context.Context, c *users.UsersClient) error {
+		_, err := c.GetUserQuota(ctx, "user-1")
+		return err
+	}},
+	{"UpdateUserAttributes", false, func(ctx context.Context, c *users.UsersClient) error {
+		return c.UpdateUserAttributes(ctx, "user-1", map[string]string{users.AttributeDepartment: "Engineering"})
+	}},
+}
+
+func TestErrorsUnwrapToAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"internal error"}`))
+	})
+
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.fn(context.Background(), client)
+			var apiErr *superclouds.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != http.StatusInternalServerError {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusInternalServerError)
+			}
+		})
+	}
+}
+
+func TestErrorsUnwrapToURLError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	client := newClientForURL(t, srv.URL)
+
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.fn(context.Background(), client)
+			var urlErr *url.Error
+			if !errors.As(err, &urlErr) {
+				t.Errorf("error = %v, want a *url.Error", err)
+			}
+		})
+	}
+}
+
+func TestErrorsUnwrapToSyntaxError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":}`))
+	})
+
+	for _, c := range calls {
+		if !c.decodes {
+			continue
+		}
+		t.Run(c.name, func(t *testing.T) {
+			err := c.fn(context.Background(), client)
+			var syntaxErr *json.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Errorf("error = %v, want a *json.SyntaxError", err)
+			}
+		})
+	}
+}
+
+func TestCreateAndUpdateUserRejectNon2xx(t *testing.T) {
+	calls := map[string]func(ctx context.Context, c *users.UsersClient) error{
+		"CreateUser": func(ctx context.Context, c *users.UsersClient) error {
+			_, err := c.CreateUser(ctx, &users.CreateUserInput{Email: "new.user@example.com"})
+			return err
+		},
+		"UpdateUser": func(ctx context.Context, c *users.UsersClient) error {
+			_, err := c.UpdateUser(ctx, &users.UpdateUserInput{FirstName: "John"})
+			return err
+		},
+	}
+
+	for _, status := range []int{http.StatusBadRequest, http.StatusInternalServerError} {
+		for name, fn := range calls {
+			t.Run(fmt.Sprintf("%s/%d", name, status), func(t *testing.T) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(status)
+					w.Write([]byte(`{"message":"request failed"}`))
+				}))
+				defer srv.Close()
+				cfg, err := superclouds.NewConfigWithOptions("", "",
+					superclouds.WithBaseURL(srv.URL),
+					superclouds.WithToken("test-token"),
+					superclouds.WithHTTPClient(srv.Client()),
+				)
+				if err != nil {
+					t.Fatalf("failed to create config: %v", err)
+				}
+
+				err = fn(context.Background(), users.NewUsersClient(cfg))
+				var apiErr *superclouds.APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("error = %v, want an *APIError", err)
+				}
+				if apiErr.StatusCode != status {
+					t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, status)
+				}
+			})
+		}
+	}
+}
+
+func TestNotFoundError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.GetUserTrustScore(context.Background(), "user-1")
+	var notFoundErr superclouds.NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("error = %v, want a NotFoundError", err)
+	}
+	want := superclouds.NotFoundError{ResourceType: "user", Identifier: "user-1"}
+	if notFoundErr != want {
+		t.Errorf("NotFoundError = %+v, want %+v", notFoundErr, want)
+	}
+}
+
+func TestCreateUserConflictError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error": "user already exists", "existing_id": "abc"}`))
+	})
+
+	_, err := client.CreateUser(context.Background(), &users.CreateUserInput{Email: "new.user@example.com"})
+	var conflictErr superclouds.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("error = %v, want a ConflictError", err)
+	}
+	if conflictErr.ExistingID != "abc" {
+		t.Errorf("ExistingID = %q, want %q", conflictErr.ExistingID, "abc")
+	}
+	if conflictErr.Message != "user already exists" {
+		t.Errorf("Message = %q, want %q", conflictErr.Message, "user already exists")
+	}
+}
+
+func TestUpdateUserValidationError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors": [
+			{"field": "first_name", "message": "too long"},
+			{"field": "contact", "message": "invalid phone number"}
+		]}`))
+	})
+
+	_, err := client.UpdateUser(context.Background(), &users.UpdateUserInput{FirstName: "John", Contact: "x"})
+	var validationErr superclouds.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want a ValidationError", err)
+	}
+	want := []superclouds.FieldError{
+		{Field: "first_name", Message: "too long"},
+		{Field: "contact", Message: "invalid phone number"},
+	}
+	if len(validationErr.Fields) != len(want) {
+		t.Fatalf("Fields = %+v, want %+v", validationErr.Fields, want)
+	}
+	for i := range want {
+		if validationErr.Fields[i] != want[i] {
+			t.Errorf("Fields[%d] = %+v, want %+v", i, validationErr.Fields[i], want[i])
+		}
+	}
+}
+
+func TestListUsersUnauthorized(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"invalid token"}`))
+	})
+
+	output, err := client.ListUsers(context.Background(), &users.ListUsersInput{})
+	var apiErr *superclouds.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("ListUsers = %v, %v, want an *APIError", output, err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "invalid token" {
+		t.Errorf("APIError = %d %q, want %d %q", apiErr.StatusCode, apiErr.Message, http.StatusUnauthorized, "invalid token")
+	}
+}
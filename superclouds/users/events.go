@@ -0,0 +1,50 @@
+package users
+
+import (
+	"context"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+)
+
+// EventPublisher publishes user change events. A UsersClient calls it after
+// each successful mutation when it is set with WithEventPublisher.
+type EventPublisher interface {
+	PublishUserCreated(ctx context.Context, user *UserOutput) error
+	PublishUserDeleted(ctx context.Context, email string) error
+	PublishRoleChanged(ctx context.Context, email, oldRole, newRole string) error
+}
+
+// eventPublisherKey is the Config value key of the EventPublisher.
+type eventPublisherKey struct{}
+
+// WithEventPublisher makes the UsersClients created with the Config publish an
+// event through p after each successful CreateUser, DeleteUser and
+// UpdateUserRole call. Publishing errors are logged and do not fail the call,
+// since the change has already been made.
+//
+// Example usage:
+//
+//	cfg, err := superclouds.NewConfigWithOptions(certPath, keyPath,
+//	    superclouds.WithToken(superToken),
+//	    users.WithEventPublisher(publisher),
+//	)
+func WithEventPublisher(p EventPublisher) superclouds.Option {
+	return func(c *superclouds.Config) {
+		c.SetValue(eventPublisherKey{}, p)
+	}
+}
+
+// eventPublisher returns the EventPublisher set on the Config, or nil.
+func eventPublisher(cfg *superclouds.Config) EventPublisher {
+	p, _ := cfg.Value(eventPublisherKey{}).(EventPublisher)
+	return p
+}
+
+// publish calls fn with the client's EventPublisher, if any, and logs its error.
+func (c *UsersClient) publish(event string, fn func(p EventPublisher) error) {
+	if c.publisher == nil {
+		return
+	}
+	if err := fn(c.publisher); err != nil {
+		c.config.Logger().Error("failed to publish user event", "event", event, "error", err)
+	}
+}
@@ -0,0 +1,52 @@
+package users
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+)
+
+// userGob is the gob form of User. It has the fields of User without its
+// methods, so that encoding it does not call User.GobEncode again.
+type userGob struct {
+	Id          string
+	Email       string
+	FirstName   string
+	LastName    string
+	Role        Role
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	LastLoginAt *time.Time
+	ETag        string
+}
+
+// GobEncode implements gob.GobEncoder. The timestamps are encoded with their
+// location offset, and a nil LastLoginAt is kept nil.
+func (u User) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(userGob(u)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder.
+func (u *User) GobDecode(data []byte) error {
+	var g userGob
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+	*u = User(g)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (o UserOutput) GobEncode() ([]byte, error) {
+	return o.User.GobEncode()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (o *UserOutput) GobDecode(data []byte) error {
+	return o.User.GobDecode(data)
+}
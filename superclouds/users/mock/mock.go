@@ -0,0 +1,483 @@
+// Package mock provides MockUsersClient, an implementation of
+// users.UsersService for testing application code without an HTTP server.
+//
+// Each method of the service is answered by the handler set with the
+// matching On method, and its inputs are recorded for the matching Calls
+// method. Methods without a handler fail with an error.
+package mock
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"iter"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// MergeUsersCall records a call to MergeUsers.
+type MergeUsersCall struct {
+	SourceID string
+	TargetID string
+}
+
+// UpdateUserAttributesCall records a call to UpdateUserAttributes.
+type UpdateUserAttributesCall struct {
+	UserID string
+	Attrs  map[string]string
+}
+
+// MockUsersClient is a users.UsersService whose methods are answered by handlers.
+// It is safe for concurrent use.
+//
+// Example usage:
+//
+//	client := &mock.MockUsersClient{}
+//	client.OnGetUser(func() (*users.UserOutput, error) {
+//	    return &users.UserOutput{User: users.User{Email: "jane@example.com"}}, nil
+//	})
+//	app := NewApp(client)
+//	app.ShowProfile(ctx)
+//	client.AssertExpectations(t)
+type MockUsersClient struct {
+	mu sync.Mutex
+
+	listUsers            func(*users.ListUsersInput) (*users.ListUsersOutput, error)
+	usersSeq             func(*users.ListUsersInput) iter.Seq2[users.User, error]
+	createUser           func(*users.CreateUserInput) (*users.UserOutput, error)
+	deleteUser           func(*users.DeleteUserInput) error
+	updateUser           func(*users.UpdateUserInput) (*users.UserOutput, error)
+	getUser              func() (*users.UserOutput, error)
+	updateUserRole       func(*users.UpdateUserRoleInput) error
+	changePassword       func(*users.ChangePasswordInput) error
+	mergeUsers           func(sourceID, targetID string) error
+	getUserTrustScore    func(userID string) (*users.TrustScore, error)
+	getUserQuota         func(userID string) (*users.UserQuota, error)
+	updateUserAttributes func(userID string, attrs map[string]string) error
+
+	listUsersCalls            []users.ListUsersInput
+	usersCalls                []users.ListUsersInput
+	createUserCalls           []users.CreateUserInput
+	deleteUserCalls           []users.DeleteUserInput
+	updateUserCalls           []users.UpdateUserInput
+	getUserCalls              int
+	updateUserRoleCalls       []users.UpdateUserRoleInput
+	changePasswordCalls       []users.ChangePasswordInput
+	mergeUsersCalls           []MergeUsersCall
+	getUserTrustScoreCalls    []string
+	getUserQuotaCalls         []string
+	updateUserAttributesCalls []UpdateUserAttributesCall
+
+	// expected lists the methods with a handler, for AssertExpectations.
+	expected map[string]bool
+	// called lists the methods that have been called.
+	called map[string]bool
+}
+
+// MockUsersClient implements users.UsersService.
+var _ users.UsersService = (*MockUsersClient)(nil)
+
+// expect records that method has a handler. It must be called with m.mu held.
+func (m *MockUsersClient) expect(method string) {
+	if m.expected == nil {
+		m.expected = make(map[string]bool)
+	}
+	m.expected[method] = true
+}
+
+// call records a call to method. It must be called with m.mu held.
+func (m *MockUsersClient) call(method string) {
+	if m.called == nil {
+		m.called = make(map[string]bool)
+	}
+	m.called[method] = true
+}
+
+// unexpected returns the error of a call to a method without a handler.
+func unexpected(method string) error {
+	return fmt.Errorf("mock: unexpected call to %s", method)
+}
+
+// AssertExpectations fails t for each method that has a handler but has not been called.
+func (m *MockUsersClient) AssertExpectations(t testing.TB) {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	methods := make([]string, 0, len(m.expected))
+	for method := range m.expected {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		if !m.called[method] {
+			t.Errorf("mock: %s has a handler but was not called", method)
+		}
+	}
+}
+
+// OnListUsers sets the handler of ListUsers.
+func (m *MockUsersClient) OnListUsers(fn func(*users.ListUsersInput) (*users.ListUsersOutput, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listUsers = fn
+	m.expect("ListUsers")
+}
+
+// ListUsersCalls returns the inputs of the calls to ListUsers.
+func (m *MockUsersClient) ListUsersCalls() []users.ListUsersInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]users.ListUsersInput(nil), m.listUsersCalls...)
+}
+
+// ListUsers implements users.UsersService.
+func (m *MockUsersClient) ListUsers(_ context.Context, input *users.ListUsersInput) (*users.ListUsersOutput, error) {
+	m.mu.Lock()
+	m.listUsersCalls = append(m.listUsersCalls, valueOf(input))
+	m.call("ListUsers")
+	fn := m.listUsers
+	m.mu.Unlock()
+
+	if fn == nil {
+		return nil, unexpected("ListUsers")
+	}
+	return fn(input)
+}
+
+// OnUsers sets the handler of Users.
+func (m *MockUsersClient) OnUsers(fn func(*users.ListUsersInput) iter.Seq2[users.User, error]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usersSeq = fn
+	m.expect("Users")
+}
+
+// UsersCalls returns the inputs of the calls to Users.
+func (m *MockUsersClient) UsersCalls() []users.ListUsersInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]users.ListUsersInput(nil), m.usersCalls...)
+}
+
+// Users implements users.UsersService. Without a handler, the sequence yields a single error.
+func (m *MockUsersClient) Users(_ context.Context, input *users.ListUsersInput) iter.Seq2[users.User, error] {
+	m.mu.Lock()
+	m.usersCalls = append(m.usersCalls, valueOf(input))
+	m.call("Users")
+	fn := m.usersSeq
+	m.mu.Unlock()
+
+	if fn == nil {
+		return func(yield func(users.User, error) bool) {
+			yield(users.User{}, unexpected("Users"))
+		}
+	}
+	return fn(input)
+}
+
+// OnCreateUser sets the handler of CreateUser.
+func (m *MockUsersClient) OnCreateUser(fn func(*users.CreateUserInput) (*users.UserOutput, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.createUser = fn
+	m.expect("CreateUser")
+}
+
+// CreateUserCalls returns the inputs of the calls to CreateUser.
+func (m *MockUsersClient) CreateUserCalls() []users.CreateUserInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]users.CreateUserInput(nil), m.createUserCalls...)
+}
+
+// CreateUser implements users.UsersService.
+func (m *MockUsersClient) CreateUser(_ context.Context, input *users.CreateUserInput) (*users.UserOutput, error) {
+	m.mu.Lock()
+	m.createUserCalls = append(m.createUserCalls, valueOf(input))
+	m.call("CreateUser")
+	fn := m.createUser
+	m.mu.Unlock()
+
+	if fn == nil {
+		return nil, unexpected("CreateUser")
+	}
+	return fn(input)
+}
+
+// OnDeleteUser sets the handler of DeleteUser.
+func (m *MockUsersClient) OnDeleteUser(fn func(*users.DeleteUserInput) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleteUser = fn
+	m.expect("DeleteUser")
+}
+
+// DeleteUserCalls returns the inputs of the calls to DeleteUser.
+func (m *MockUsersClient) DeleteUserCalls() []users.DeleteUserInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]users.DeleteUserInput(nil), m.deleteUserCalls...)
+}
+
+// DeleteUser implements users.UsersService.
+func (m *MockUsersClient) DeleteUser(_ context.Context, input *users.DeleteUserInput) error {
+	m.mu.Lock()
+	m.deleteUserCalls = append(m.deleteUserCalls, valueOf(input))
+	m.call("DeleteUser")
+	fn := m.deleteUser
+	m.mu.Unlock()
+
+	if fn == nil {
+		return unexpected("DeleteUser")
+	}
+	return fn(input)
+}
+
+// OnUpdateUser sets the handler of UpdateUser.
+func (m *MockUsersClient) OnUpdateUser(fn func(*users.UpdateUserInput) (*users.UserOutput, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateUser = fn
+	m.expect("UpdateUser")
+}
+
+// UpdateUserCalls returns the inputs of the calls to UpdateUser.
+func (m *MockUsersClient) UpdateUserCalls() []users.UpdateUserInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]users.UpdateUserInput(nil), m.updateUserCalls...)
+}
+
+// UpdateUser implements users.UsersService.
+func (m *MockUsersClient) UpdateUser(_ context.Context, input *users.UpdateUserInput) (*users.UserOutput, error) {
+	m.mu.Lock()
+	m.updateUserCalls = append(m.updateUserCalls, valueOf(input))
+	m.call("UpdateUser")
+	fn := m.updateUser
+	m.mu.Unlock()
+
+	if fn == nil {
+		return nil, unexpected("UpdateUser")
+	}
+	return fn(input)
+}
+
+// OnGetUser sets the handler of GetUser.
+func (m *MockUsersClient) OnGetUser(fn func() (*users.UserOutput, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.getUser = fn
+	m.expect("GetUser")
+}
+
+// GetUserCalls returns the number of calls to GetUser, which has no input.
+func (m *MockUsersClient) GetUserCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.getUserCalls
+}
+
+// GetUser implements users.UsersService.
+func (m *MockUsersClient) GetUser(_ context.Context) (*users.UserOutput, error) {
+	m.mu.Lock()
+	m.getUserCalls++
+	m.call("GetUser")
+	fn := m.getUser
+	m.mu.Unlock()
+
+	if fn == nil {
+		return nil, unexpected("GetUser")
+	}
+	return fn()
+}
+
+// OnUpdateUserRole sets the handler of UpdateUserRole.
+func (m *MockUsersClient) OnUpdateUserRole(fn func(*users.UpdateUserRoleInput) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateUserRole = fn
+	m.expect("UpdateUserRole")
+}
+
+// UpdateUserRoleCalls returns the inputs of the calls to UpdateUserRole.
+func (m *MockUsersClient) UpdateUserRoleCalls() []users.UpdateUserRoleInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]users.UpdateUserRoleInput(nil), m.updateUserRoleCalls...)
+}
+
+// UpdateUserRole implements users.UsersService.
+func (m *MockUsersClient) UpdateUserRole(_ context.Context, input *users.UpdateUserRoleInput) error {
+	m.mu.Lock()
+	m.updateUserRoleCalls = append(m.updateUserRoleCalls, valueOf(input))
+	m.call("UpdateUserRole")
+	fn := m.updateUserRole
+	m.mu.Unlock()
+
+	if fn == nil {
+		return unexpected("UpdateUserRole")
+	}
+	return fn(input)
+}
+
+// OnChangePassword sets the handler of ChangePassword.
+func (m *MockUsersClient) OnChangePassword(fn func(*users.ChangePasswordInput) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.changePassword = fn
+	m.expect("ChangePassword")
+}
+
+// ChangePasswordCalls returns the inputs of the calls to ChangePassword.
+func (m *MockUsersClient) ChangePasswordCalls() []users.ChangePasswordInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]users.ChangePasswordInput(nil), m.changePasswordCalls...)
+}
+
+// ChangePassword implements users.UsersService.
+func (m *MockUsersClient) ChangePassword(_ context.Context, input *users.ChangePasswordInput) error {
+	m.mu.Lock()
+	m.changePasswordCalls = append(m.changePasswordCalls, valueOf(input))
+	m.call("ChangePassword")
+	fn := m.changePassword
+	m.mu.Unlock()
+
+	if fn == nil {
+		return unexpected("ChangePassword")
+	}
+	return fn(input)
+}
+
+// OnMergeUsers sets the handler of MergeUsers.
+func (m *MockUsersClient) OnMergeUsers(fn func(sourceID, targetID string) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mergeUsers = fn
+	m.expect("MergeUsers")
+}
+
+// MergeUsersCalls returns the inputs of the calls to MergeUsers.
+func (m *MockUsersClient) MergeUsersCalls() []MergeUsersCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MergeUsersCall(nil), m.mergeUsersCalls...)
+}
+
+// MergeUsers implements users.UsersService.
+func (m *MockUsersClient) MergeUsers(_ context.Context, sourceID, targetID string) error {
+	m.mu.Lock()
+	m.mergeUsersCalls = append(m.mergeUsersCalls, MergeUsersCall{SourceID: sourceID, TargetID: targetID})
+	m.call("MergeUsers")
+	fn := m.mergeUsers
+	m.mu.Unlock()
+
+	if fn == nil {
+		return unexpected("MergeUsers")
+	}
+	return fn(sourceID, targetID)
+}
+
+// OnGetUserTrustScore sets the handler of GetUserTrustScore.
+func (m *MockUsersClient) OnGetUserTrustScore(fn func(userID string) (*users.TrustScore, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.getUserTrustScore = fn
+	m.expect("GetUserTrustScore")
+}
+
+// GetUserTrustScoreCalls returns the user IDs of the calls to GetUserTrustScore.
+func (m *MockUsersClient) GetUserTrustScoreCalls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.getUserTrustScoreCalls...)
+}
+
+// GetUserTrustScore implements users.UsersService.
+func (m *MockUsersClient) GetUserTrustScore(_ context.Context, userID string) (*users.TrustScore, error) {
+	m.mu.Lock()
+	m.getUserTrustScoreCalls = append(m.getUserTrustScoreCalls, userID)
+	m.call("GetUserTrustScore")
+	fn := m.getUserTrustScore
+	m.mu.Unlock()
+
+	if fn == nil {
+		return nil, unexpected("GetUserTrustScore")
+	}
+	return fn(userID)
+}
+
+// OnGetUserQuota sets the handler of GetUserQuota.
+func (m *MockUsersClient) OnGetUserQuota(fn func(userID string) (*users.UserQuota, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.getUserQuota = fn
+	m.expect("GetUserQuota")
+}
+
+// GetUserQuotaCalls returns the user IDs of the calls to GetUserQuota.
+func (m *MockUsersClient) GetUserQuotaCalls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.getUserQuotaCalls...)
+}
+
+// GetUserQuota implements users.UsersService.
+func (m *MockUsersClient) GetUserQuota(_ context.Context, userID string) (*users.UserQuota, error) {
+	m.mu.Lock()
+	m.getUserQuotaCalls = append(m.getUserQuotaCalls, userID)
+	m.call("GetUserQuota")
+	fn := m.getUserQuota
+	m.mu.Unlock()
+
+	if fn == nil {
+		return nil, unexpected("GetUserQuota")
+	}
+	return fn(userID)
+}
+
+// OnUpdateUserAttributes sets the handler of UpdateUserAttributes.
+func (m *MockUsersClient) OnUpdateUserAttributes(fn func(userID string, attrs map[string]string) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateUserAttributes = fn
+	m.expect("UpdateUserAttributes")
+}
+
+// UpdateUserAttributesCalls returns the inputs of the calls to UpdateUserAttributes.
+func (m *MockUsersClient) UpdateUserAttributesCalls() []UpdateUserAttributesCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]UpdateUserAttributesCall(nil), m.updateUserAttributesCalls...)
+}
+
+// UpdateUserAttributes implements users.UsersService.
+func (m *MockUsersClient) UpdateUserAttributes(_ context.Context, userID string, attrs map[string]string) error {
+	copied := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		copied[k] = v
+	}
+
+	m.mu.Lock()
+	m.updateUserAttributesCalls = append(m.updateUserAttributesCalls, UpdateUserAttributesCall{UserID: userID, Attrs: copied})
+	m.call("UpdateUserAttributes")
+	fn := m.updateUserAttributes
+	m.mu.Unlock()
+
+	if fn == nil {
+		return unexpected("UpdateUserAttributes")
+	}
+	return fn(userID, attrs)
+}
+
+// valueOf returns a copy of *input, or the zero value if input is nil,
+// so that recorded inputs are not affected by later changes.
+func valueOf[T any](input *T) T {
+	var v T
+	if input != nil {
+		v = *input
+	}
+	return v
+}
@@ -0,0 +1,86 @@
+package mock_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users/mock"
+	"testing"
+)
+
+// countAdmins is application code under test: it depends on users.UsersService only.
+func countAdmins(ctx context.Context, service users.UsersService) (int, error) {
+	output, err := service.ListUsers(ctx, &users.ListUsersInput{Role: users.RoleAdmin})
+	if err != nil {
+		return 0, err
+	}
+	return len(output.Users), nil
+}
+
+func ExampleMockUsersClient() {
+	client := &mock.MockUsersClient{}
+	client.OnListUsers(func(input *users.ListUsersInput) (*users.ListUsersOutput, error) {
+		return &users.ListUsersOutput{Users: []users.User{
+			{Email: "jane@example.com"},
+			{Email: "john@example.com"},
+		}}, nil
+	})
+
+	n, err := countAdmins(context.Background(), client)
+	fmt.Println(n, err)
+	fmt.Println(client.ListUsersCalls()[0].Role)
+	// Output:
+	// 2 <nil>
+	// ADMIN
+}
+
+func ExampleMockUsersClient_OnGetUser() {
+	client := &mock.MockUsersClient{}
+	client.OnGetUser(func() (*users.UserOutput, error) {
+		return nil, fmt.Errorf("service unavailable")
+	})
+
+	_, err := client.GetUser(context.Background())
+	fmt.Println(err)
+	// Output:
+	// service unavailable
+}
+
+func ExampleMockUsersClient_unexpectedCall() {
+	client := &mock.MockUsersClient{}
+
+	err := client.DeleteUser(context.Background(), &users.DeleteUserInput{Email: "jane@example.com"})
+	fmt.Println(err)
+	fmt.Println(client.DeleteUserCalls()[0].Email)
+	// Output:
+	// mock: unexpected call to DeleteUser
+	// jane@example.com
+}
+
+// recordingT records the failures reported to it instead of failing the test.
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertExpectations(t *testing.T) {
+	client := &mock.MockUsersClient{}
+	client.OnGetUser(func() (*users.UserOutput, error) { return &users.UserOutput{}, nil })
+	client.OnCreateUser(func(*users.CreateUserInput) (*users.UserOutput, error) { return &users.UserOutput{}, nil })
+
+	if _, err := client.GetUser(context.Background()); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+
+	rt := &recordingT{TB: t}
+	client.AssertExpectations(rt)
+	if len(rt.failures) != 1 || rt.failures[0] != "mock: CreateUser has a handler but was not called" {
+		t.Errorf("failures = %q, want one for CreateUser", rt.failures)
+	}
+}
@@ -0,0 +1,215 @@
+package users
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds/cursor"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/proto/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// ToProto converts the user to its Protocol Buffer message.
+func (u *User) ToProto() *pb.User {
+	p := &pb.User{
+		Id:        u.Id,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Role:      uint32(u.Role),
+		Status:    u.Status,
+		CreatedAt: timestampToProto(u.CreatedAt),
+		UpdatedAt: timestampToProto(u.UpdatedAt),
+		Etag:      u.ETag,
+	}
+	if u.LastLoginAt != nil {
+		p.LastLoginAt = timestamppb.New(*u.LastLoginAt)
+	}
+	return p
+}
+
+// FromProto sets the user from its Protocol Buffer message.
+func (u *User) FromProto(p *pb.User) {
+	*u = User{
+		Id:        p.GetId(),
+		Email:     p.GetEmail(),
+		FirstName: p.GetFirstName(),
+		LastName:  p.GetLastName(),
+		Role:      Role(p.GetRole()),
+		Status:    p.GetStatus(),
+		CreatedAt: timestampFromProto(p.GetCreatedAt()),
+		UpdatedAt: timestampFromProto(p.GetUpdatedAt()),
+		ETag:      p.GetEtag(),
+	}
+	if p.GetLastLoginAt() != nil {
+		lastLoginAt := p.GetLastLoginAt().AsTime()
+		u.LastLoginAt = &lastLoginAt
+	}
+}
+
+// ToProto converts the input to its Protocol Buffer message.
+func (i *ListUsersInput) ToProto() *pb.ListUsersInput {
+	return &pb.ListUsersInput{
+		Size:       int32(i.Size),
+		Page:       int32(i.Page),
+		SearchTerm: i.SearchTerm,
+		Role:       string(i.Role),
+		SortBy:     i.SortBy,
+		SortOrder:  i.SortOrder,
+		Cursor:     i.Cursor,
+		PageToken:  i.PageToken,
+		FieldMask:  i.FieldMask,
+	}
+}
+
+// FromProto sets the input from its Protocol Buffer message.
+func (i *ListUsersInput) FromProto(p *pb.ListUsersInput) {
+	*i = ListUsersInput{
+		Size:       int(p.GetSize()),
+		Page:       int(p.GetPage()),
+		SearchTerm: p.GetSearchTerm(),
+		Role:       RoleName(p.GetRole()),
+		SortBy:     p.GetSortBy(),
+		SortOrder:  p.GetSortOrder(),
+		Cursor:     p.GetCursor(),
+		PageToken:  p.GetPageToken(),
+		FieldMask:  p.GetFieldMask(),
+	}
+}
+
+// ToProto converts the output to its Protocol Buffer message.
+func (o *ListUsersOutput) ToProto() *pb.ListUsersOutput {
+	users := make([]*pb.User, len(o.Users))
+	for i := range o.Users {
+		users[i] = o.Users[i].ToProto()
+	}
+	return &pb.ListUsersOutput{
+		Users:         users,
+		Page:          int32(o.PageNumber),
+		Pages:         int32(o.Pages),
+		Size:          int32(o.Size),
+		NextCursor:    o.NextCursor,
+		HasMore:       o.HasMore,
+		TotalCount:    int32(o.TotalCount),
+		NextPageToken: o.NextPageToken,
+	}
+}
+
+// FromProto sets the output from its Protocol Buffer message.
+func (o *ListUsersOutput) FromProto(p *pb.ListUsersOutput) {
+	users := make([]User, len(p.GetUsers()))
+	for i, user := range p.GetUsers() {
+		users[i].FromProto(user)
+	}
+	*o = ListUsersOutput{
+		Page: cursor.Page[User]{
+			Items:      users,
+			NextCursor: p.GetNextCursor(),
+			HasMore:    p.GetHasMore(),
+			TotalCount: int(p.GetTotalCount()),
+		},
+		Users:         users,
+		PageNumber:    int(p.GetPage()),
+		Pages:         int(p.GetPages()),
+		Size:          int(p.GetSize()),
+		NextPageToken: p.GetNextPageToken(),
+	}
+}
+
+// ToProto converts the input to its Protocol Buffer message.
+func (i *CreateUserInput) ToProto() *pb.CreateUserInput {
+	return &pb.CreateUserInput{
+		Email:     i.Email,
+		FirstName: i.FirstName,
+		LastName:  i.LastName,
+		Role:      string(i.Role),
+	}
+}
+
+// FromProto sets the input from its Protocol Buffer message.
+func (i *CreateUserInput) FromProto(p *pb.CreateUserInput) {
+	*i = CreateUserInput{
+		Email:     p.GetEmail(),
+		FirstName: p.GetFirstName(),
+		LastName:  p.GetLastName(),
+		Role:      RoleName(p.GetRole()),
+	}
+}
+
+// ToProto converts the output to its Protocol Buffer message.
+func (o *UserOutput) ToProto() *pb.UserOutput {
+	return &pb.UserOutput{User: o.User.ToProto()}
+}
+
+// FromProto sets the output from its Protocol Buffer message.
+func (o *UserOutput) FromProto(p *pb.UserOutput) {
+	o.User.FromProto(p.GetUser())
+}
+
+// ToProto converts the input to its Protocol Buffer message.
+func (i *UpdateUserInput) ToProto() *pb.UpdateUserInput {
+	return &pb.UpdateUserInput{
+		FirstName: i.FirstName,
+		LastName:  i.LastName,
+		Contact:   i.Contact,
+		IfMatch:   i.IfMatch,
+	}
+}
+
+// FromProto sets the input from its Protocol Buffer message.
+func (i *UpdateUserInput) FromProto(p *pb.UpdateUserInput) {
+	*i = UpdateUserInput{
+		FirstName: p.GetFirstName(),
+		LastName:  p.GetLastName(),
+		Contact:   p.GetContact(),
+		IfMatch:   p.GetIfMatch(),
+	}
+}
+
+// ToProto converts the input to its Protocol Buffer message.
+func (i *DeleteUserInput) ToProto() *pb.DeleteUserInput {
+	return &pb.DeleteUserInput{
+		Email:      i.Email,
+		ApprovalId: i.ApprovalID,
+	}
+}
+
+// FromProto sets the input from its Protocol Buffer message.
+func (i *DeleteUserInput) FromProto(p *pb.DeleteUserInput) {
+	*i = DeleteUserInput{
+		Email:      p.GetEmail(),
+		ApprovalID: p.GetApprovalId(),
+	}
+}
+
+// ToProto converts the input to its Protocol Buffer message.
+func (i *UpdateUserRoleInput) ToProto() *pb.UpdateUserRoleInput {
+	return &pb.UpdateUserRoleInput{
+		Email:      i.Email,
+		Role:       i.Role,
+		ApprovalId: i.ApprovalID,
+	}
+}
+
+// FromProto sets the input from its Protocol Buffer message.
+func (i *UpdateUserRoleInput) FromProto(p *pb.UpdateUserRoleInput) {
+	*i = UpdateUserRoleInput{
+		Email:      p.GetEmail(),
+		Role:       p.GetRole(),
+		ApprovalID: p.GetApprovalId(),
+	}
+}
+
+// timestampToProto converts t to a Timestamp, leaving the zero time unset.
+func timestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timestampFromProto converts ts to a time, returning the zero time when ts is unset.
+func timestampFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
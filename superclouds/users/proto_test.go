@@ -0,0 +1,46 @@
+package users_test
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds/proto/pb"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestUserProtoETag(t *testing.T) {
+	user := users.User{Id: "user-1", Email: "user@example.com", ETag: `"v42"`}
+
+	data, err := proto.Marshal(user.ToProto())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var p pb.User
+	if err := proto.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	var got users.User
+	got.FromProto(&p)
+	if got.ETag != user.ETag {
+		t.Errorf("ETag = %q, want %q", got.ETag, user.ETag)
+	}
+}
+
+func TestUpdateUserInputProtoIfMatch(t *testing.T) {
+	input := users.UpdateUserInput{FirstName: "John", IfMatch: `"v42"`}
+
+	data, err := proto.Marshal(input.ToProto())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var p pb.UpdateUserInput
+	if err := proto.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	var got users.UpdateUserInput
+	got.FromProto(&p)
+	if got != input {
+		t.Errorf("UpdateUserInput = %+v, want %+v", got, input)
+	}
+}
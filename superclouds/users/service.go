@@ -0,0 +1,35 @@
+package users
+
+import (
+	"context"
+	"iter"
+)
+
+// UsersService is the set of methods of UsersClient. Code that depends on
+// UsersService instead of *UsersClient can be tested with a fake implementation.
+type UsersService interface {
+	ListUsers(ctx context.Context, input *ListUsersInput) (*ListUsersOutput, error)
+	Users(ctx context.Context, input *ListUsersInput) iter.Seq2[User, error]
+	CreateUser(ctx context.Context, input *CreateUserInput) (*UserOutput, error)
+	DeleteUser(ctx context.Context, input *DeleteUserInput) error
+	UpdateUser(ctx context.Context, input *UpdateUserInput) (*UserOutput, error)
+	GetUser(ctx context.Context) (*UserOutput, error)
+	UpdateUserRole(ctx context.Context, input *UpdateUserRoleInput) error
+	ChangePassword(ctx context.Context, input *ChangePasswordInput) error
+	MergeUsers(ctx context.Context, sourceID, targetID string) error
+	GetUserTrustScore(ctx context.Context, userID string) (*TrustScore, error)
+	GetUserQuota(ctx context.Context, userID string) (*UserQuota, error)
+	UpdateUserAttributes(ctx context.Context, userID string, attrs map[string]string) error
+}
+
+// UsersClient implements UsersService.
+var _ UsersService = (*UsersClient)(nil)
+
+// AsService returns the client as a UsersService.
+//
+// Example usage:
+//
+//	var service users.UsersService = users.NewUsersClient(cfg).AsService()
+func (c *UsersClient) AsService() UsersService {
+	return c
+}
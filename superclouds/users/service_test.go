@@ -0,0 +1,16 @@
+package users_test
+
+import (
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"testing"
+)
+
+// UsersClient satisfies UsersService; the build fails otherwise.
+var _ users.UsersService = (*users.UsersClient)(nil)
+
+func TestAsService(t *testing.T) {
+	client := newClientForURL(t, "https://api.example.com/api/v1")
+	if service := client.AsService(); service != users.UsersService(client) {
+		t.Errorf("AsService() = %v, want the client itself", service)
+	}
+}
@@ -1,54 +1,176 @@
 package users
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/cursor"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/generic"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/pagination"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/schema/registry"
+	"iter"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
+	"time"
 )
 
 // UsersClient provides methods to interact with the users endpoint of the Superclouds API.
 type UsersClient struct {
-	config *superclouds.Config
+	config    *superclouds.Config
+	publisher EventPublisher
+}
+
+// UsersClientOption customises a UsersClient created by NewUsersClient.
+// The options apply to a copy of the Config, so other clients sharing it are not affected.
+type UsersClientOption func(*UsersClient)
+
+// WithUsersHTTPClient sets the HTTP client used by the UsersClient. As with
+// superclouds.WithHTTPClient, the client must already be set up for mutual
+// TLS; the settings of the Config, such as retries, are applied around its transport.
+func WithUsersHTTPClient(client *http.Client) UsersClientOption {
+	return func(c *UsersClient) {
+		c.config.Client = client
+	}
+}
+
+// WithUsersBaseURL overrides the base URL of the Superclouds API for the UsersClient.
+func WithUsersBaseURL(u string) UsersClientOption {
+	return func(c *UsersClient) {
+		c.config.SuperURL = u
+	}
+}
+
+// WithUsersLogger sets the Logger that receives the log messages of the
+// UsersClient, such as event publishing failures. The transport of the
+// Config keeps logging to the Logger of the Config, unless an HTTP client is
+// also set with WithUsersHTTPClient.
+func WithUsersLogger(logger superclouds.Logger) UsersClientOption {
+	return func(c *UsersClient) {
+		superclouds.WithLogger(logger)(c.config)
+	}
 }
 
 // NewUsersClient creates a new UsersClient instance with the provided configuration.
 //
 // Parameters:
 // - cfg: The configuration instance created using NewConfig or NewConfigWithParams.
+// - opts: The options to apply, such as WithUsersBaseURL.
 //
 // Example usage:
 //
-//	usersClient := superclouds.NewUsersClient(cfg)
-func NewUsersClient(cfg *superclouds.Config) *UsersClient {
-	return &UsersClient{config: cfg}
+//	usersClient := users.NewUsersClient(cfg,
+//	    users.WithUsersBaseURL("https://users.superclouds.example/v1"),
+//	)
+func NewUsersClient(cfg *superclouds.Config, opts ...UsersClientOption) *UsersClient {
+	c := &UsersClient{config: cfg}
+	if len(opts) > 0 {
+		c.config = cfg.Clone()
+		client := c.config.Client
+		for _, opt := range opts {
+			opt(c)
+		}
+		if c.config.Client != client && c.config.Client != nil {
+			c.config.Client = c.config.WrapHTTPClient(c.config.Client)
+		}
+	}
+	c.publisher = eventPublisher(c.config)
+	return c
 }
 
 // SuperAPIResponse represents the structure of the response from the Superclouds API.
-type SuperAPIResponse struct {
-	Data    interface{} `json:"data"`
-	Status  int         `json:"status"`
-	Message string      `json:"message"`
-	Errors  []string    `json:"errors"`
-	Page    int         `json:"page"`
-	Pages   int         `json:"pages"`
-	Size    int         `json:"size"`
-	Total   int         `json:"total"`
-}
+type SuperAPIResponse = generic.Response[interface{}]
 
 // ListUsersInput defines the input parameters for the ListUsers method.
 type ListUsersInput struct {
-	Size       int    `json:"size"`
-	Page       int    `json:"page"`
-	SearchTerm string `json:"s"`
+	Size       int      `json:"size"`
+	Page       int      `json:"page"`
+	SearchTerm string   `json:"s"`
+	Role       RoleName `json:"role"`
+	SortBy     string   `json:"sort_by"`
+	SortOrder  string   `json:"sort_order"`
+	// Cursor is the NextCursor of a previous page. When set, it takes precedence over Page.
+	Cursor string `json:"cursor"`
+	// PageToken is the NextPageToken of a previous page, as an alternative to Page.
+	// When set, it replaces Page and Size.
+	PageToken string `json:"-"`
+	// FieldMask lists the JSON field names to return for each user, such as "id" and "email".
+	// When empty, the FieldMask of the Config is used, and all fields are returned if both are empty.
+	FieldMask []string `json:"fields"`
+}
+
+// Sort orders accepted by the SortOrder field of ListUsersInput.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// Fields accepted by the SortBy field of ListUsersInput.
+const (
+	SortByEmail     = "email"
+	SortByCreatedAt = "created_at"
+	SortByLastName  = "last_name"
+)
+
+// Validate checks that the input parameters are acceptable before a request is made.
+func (i *ListUsersInput) Validate() error {
+	if i.PageToken != "" {
+		if _, _, _, err := pagination.DecodeToken(i.PageToken); err != nil {
+			return err
+		}
+	}
+	if i.SortOrder != "" && i.SortOrder != SortAsc && i.SortOrder != SortDesc {
+		return fmt.Errorf("invalid sort order %q: must be %q or %q", i.SortOrder, SortAsc, SortDesc)
+	}
+	return nil
 }
 
 // ListUsersOutput defines the output structure for the ListUsers method.
+// The embedded cursor.Page holds the users as Items together with the cursor
+// and total count; Users is the same slice, kept for existing callers.
+// HasMore is false once the last page has been fetched, which makes it the
+// termination condition for manual pagination.
 type ListUsersOutput struct {
-	Users []User `json:"data"`
+	cursor.Page[User]
+	Users      []User `json:"data"`
+	PageNumber int    `json:"page"`
+	Pages      int    `json:"pages"`
+	Size       int    `json:"size"`
+	// NextPageToken can be passed as the PageToken of the next call; it is empty on the last page.
+	NextPageToken string `json:"-"`
+
+	// logger receives the warning logged by Deduplicate.
+	logger superclouds.Logger
+}
+
+// Deduplicate returns a copy of the output without users whose Id was already
+// seen, keeping the first occurrence. Paging bugs on the server side can
+// return the same user on consecutive pages; a warning is logged through the
+// client's Logger when duplicates are dropped.
+//
+// Example usage:
+//
+//	usersOutput = usersOutput.Deduplicate()
+func (o *ListUsersOutput) Deduplicate() *ListUsersOutput {
+	seen := make(map[string]bool, len(o.Users))
+	deduplicated := make([]User, 0, len(o.Users))
+	for _, user := range o.Users {
+		if seen[user.Id] {
+			continue
+		}
+		seen[user.Id] = true
+		deduplicated = append(deduplicated, user)
+	}
+
+	if removed := len(o.Users) - len(deduplicated); removed > 0 && o.logger != nil {
+		o.logger.Warn("removed duplicate users from page", "removed", removed, "page", o.PageNumber)
+	}
+
+	output := *o
+	output.Items = deduplicated
+	output.Users = deduplicated
+	return &output
 }
 
 type Role uint
@@ -61,45 +183,101 @@ const (
 	SUPER
 )
 
+// RoleName is the name of a role, as accepted by the Role fields of
+// ListUsersInput and CreateUserInput. Unlike Role, the numeric permission set
+// carried by User, it is sent to the API as a string.
+type RoleName string
+
+// Role names known to the API.
+const (
+	RoleAdmin  RoleName = "ADMIN"
+	RoleModify RoleName = "MODIFY"
+	RoleView   RoleName = "VIEW"
+)
+
+// Valid reports whether n is one of the known role names.
+func (n RoleName) Valid() bool {
+	switch n {
+	case RoleAdmin, RoleModify, RoleView:
+		return true
+	}
+	return false
+}
+
 // User represents a user in the Superclouds system.
+// LastLoginAt is nil for users who have never logged in.
+// ETag identifies the version of the user; it is set from the ETag response
+// header by CreateUser, UpdateUser and GetUser, and is empty in lists.
 type User struct {
-	Id        string `json:"id"`
-	Email     string `json:"email"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Role      Role   `json:"role"`
+	Id          string     `json:"id"`
+	Email       string     `json:"email"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	Role        Role       `json:"role"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at,omitzero"`
+	UpdatedAt   time.Time  `json:"updated_at,omitzero"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	ETag        string     `json:"-"`
 }
 
 // CreateUserInput defines the input parameters for the CreateUser method.
+// FirstName, LastName and Role are optional and are only sent when set.
 type CreateUserInput struct {
-	Email string `json:"email"`
+	Email     string   `json:"email"`
+	FirstName string   `json:"first_name,omitempty"`
+	LastName  string   `json:"last_name,omitempty"`
+	Role      RoleName `json:"role,omitempty"`
+}
+
+// Validate checks that the input parameters are acceptable before a request is made.
+func (i *CreateUserInput) Validate() error {
+	if i.Role != "" && !i.Role.Valid() {
+		return fmt.Errorf("invalid role %q: must be one of %q, %q or %q", i.Role, RoleAdmin, RoleModify, RoleView)
+	}
+	return nil
 }
 
 // DeleteUserInput defines the input parameters for the DeleteUser method.
+// ApprovalID is the ID of a granted approval, required when the organization
+// enforces approvals for this operation.
 type DeleteUserInput struct {
-	Email string `json:"email"`
+	Email      string `json:"email"`
+	ApprovalID string `json:"-"`
 }
 
 // UpdateUserInput defines the input parameters for the UpdateUser method.
+// IfMatch is optional; when set to the ETag of the user, the update fails
+// with superclouds.ErrPreconditionFailed if the user changed since.
 type UpdateUserInput struct {
 	FirstName string `json:"first_name,omitempty"`
 	LastName  string `json:"last_name,omitempty"`
 	Contact   string `json:"contact,omitempty"`
+	IfMatch   string `json:"-"`
 }
 
 // UserOutput defines the output structure for user-related methods.
+// It embeds User so that CreateUser, UpdateUser and GetUser return the full
+// user record, including its ETag.
 type UserOutput struct {
-	ID    string `json:"id"`
-	Email string `json:"email"`
-	// Add more fields as needed
+	User
 }
 
 // UpdateUserRoleInput defines the input parameters for the UpdateUserRole method.
+// ApprovalID is the ID of a granted approval, required when the organization
+// enforces approvals for this operation. PreviousRole is the role the user
+// had before the update; it is not sent to the API and is only reported to
+// the EventPublisher.
 type UpdateUserRoleInput struct {
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+	ApprovalID   string `json:"-"`
+	PreviousRole string `json:"-"`
 }
 
+// approvalIDHeader carries the approval ID of operations that require a second admin.
+const approvalIDHeader = "X-Approval-ID"
+
 // ChangePasswordInput defines the input parameters for the ChangePassword method.
 type ChangePasswordInput struct {
 	CurrentPassword string `json:"current_password"`
@@ -123,61 +301,149 @@ type ChangePasswordInput struct {
 //	    Size: 10,
 //	    Page: 1,
 //	    SearchTerm: "search_term",
+//	    Role: users.RoleAdmin,
+//	    SortBy: users.SortByEmail,
+//	    SortOrder: users.SortAsc,
 //	})
 //	if err != nil {
 //	    log.Fatalf("Failed to list users: %v", err)
 //	}
 //	log.Printf("Users: %v", usersOutput.Users)
 func (c *UsersClient) ListUsers(ctx context.Context, input *ListUsersInput) (*ListUsersOutput, error) {
+	ctx = superclouds.WithOperation(ctx, "users", "list")
+
 	if input == nil {
 		input = &ListUsersInput{}
 	}
-
-	baseURL, err := url.Parse(c.config.SuperURL)
-	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %v", err)
+	if err := input.Validate(); err != nil {
+		return nil, err
 	}
 
-	baseURL.Path += "/users"
+	page, size := input.Page, input.Size
+	if input.PageToken != "" {
+		// Validate has already checked that the token decodes.
+		page, size, _, _ = pagination.DecodeToken(input.PageToken)
+	}
 
 	params := url.Values{}
-	if input.Size > 0 {
-		params.Add("size", fmt.Sprintf("%d", input.Size))
+	if size > 0 {
+		params.Add("size", fmt.Sprintf("%d", size))
 	}
-	if input.Page > 0 {
-		params.Add("page", fmt.Sprintf("%d", input.Page))
+	if page > 0 {
+		params.Add("page", fmt.Sprintf("%d", page))
 	}
 	if input.SearchTerm != "" {
 		params.Add("s", input.SearchTerm)
 	}
-	baseURL.RawQuery = params.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
+	if input.Role != "" {
+		params.Add("role", string(input.Role))
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	if c.config.SuperToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.SuperToken)
+	if input.SortBy != "" {
+		params.Add("sort_by", input.SortBy)
+	}
+	if input.SortOrder != "" {
+		params.Add("sort_order", input.SortOrder)
+	}
+	if input.Cursor != "" {
+		params.Add("cursor", input.Cursor)
+	}
+	fieldMask := input.FieldMask
+	if len(fieldMask) == 0 {
+		fieldMask = c.config.FieldMask
+	}
+	if len(fieldMask) > 0 {
+		params.Add("fields", strings.Join(fieldMask, ","))
 	}
 
-	resp, err := c.config.Client.Do(req)
+	apiResponse, err := generic.Get[generic.Response[[]User]](ctx, c.config, "/users", params)
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %v", err)
+		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
-	defer resp.Body.Close()
 
-	var apiResponse SuperAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	nextPageToken := ""
+	if apiResponse.HasMore() {
+		nextPageToken = pagination.EncodeToken(apiResponse.Page+1, size, nil)
 	}
 
 	return &ListUsersOutput{
-		Users: apiResponse.Data.([]User),
+		Page: cursor.Page[User]{
+			Items:      apiResponse.Data,
+			NextCursor: apiResponse.NextCursor,
+			HasMore:    apiResponse.NextCursor != "" || apiResponse.HasMore(),
+			TotalCount: apiResponse.Total,
+		},
+		Users:         apiResponse.Data,
+		PageNumber:    apiResponse.Page,
+		Pages:         apiResponse.Pages,
+		Size:          apiResponse.Size,
+		NextPageToken: nextPageToken,
+		logger:        c.config.Logger(),
 	}, nil
 }
 
+// Users returns an iterator over all users matching input, fetching further
+// pages on demand. Iteration starts at input.Page (or the first page) and the
+// next page is only requested once every user of the current page has been
+// yielded. Iteration stops after the first error, which is yielded together
+// with a zero User, or when the loop body breaks.
+//
+// Parameters:
+// - ctx: The context for the requests.
+// - input: The input parameters for the requests; Page is advanced internally.
+//
+// Returns:
+// - iter.Seq2[User, error]: An iterator over users and request errors.
+//
+// Example usage:
+//
+//	for user, err := range usersClient.Users(context.TODO(), &users.ListUsersInput{Size: 50}) {
+//	    if err != nil {
+//	        log.Fatalf("Failed to list users: %v", err)
+//	    }
+//	    log.Printf("User: %v", user)
+//	}
+func (c *UsersClient) Users(ctx context.Context, input *ListUsersInput) iter.Seq2[User, error] {
+	return func(yield func(User, error) bool) {
+		pageInput := ListUsersInput{}
+		if input != nil {
+			pageInput = *input
+		}
+		if pageInput.Page < 1 {
+			pageInput.Page = 1
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(User{}, err)
+				return
+			}
+
+			output, err := c.ListUsers(ctx, &pageInput)
+			if err != nil {
+				yield(User{}, err)
+				return
+			}
+
+			for _, user := range output.Users {
+				if !yield(user, nil) {
+					return
+				}
+			}
+
+			if len(output.Users) == 0 || !output.HasMore {
+				return
+			}
+			if output.NextCursor != "" {
+				pageInput.Cursor = output.NextCursor
+			} else if pageInput.PageToken != "" {
+				pageInput.PageToken = output.NextPageToken
+			} else {
+				pageInput.Page = output.PageNumber + 1
+			}
+		}
+	}
+}
+
 // CreateUser creates a new user within the organization.
 //
 // Parameters:
@@ -186,49 +452,46 @@ func (c *UsersClient) ListUsers(ctx context.Context, input *ListUsersInput) (*Li
 //
 // Returns:
 // - UserOutput: The created user's details.
-// - error: Any error encountered during the request.
+// - error: Any error encountered during the request; a superclouds.ConflictError if a user with the email already exists.
 //
 // Example usage:
 //
 //	newUser, err := usersClient.CreateUser(context.TODO(), &users.CreateUserInput{
-//	    Email: "new.user@example.com",
+//	    Email:     "new.user@example.com",
+//	    FirstName: "John",
+//	    LastName:  "Doe",
+//	    Role:      users.RoleModify,
 //	})
 //	if err != nil {
 //	    log.Fatalf("Failed to create user: %v", err)
 //	}
 //	log.Printf("Created User: %v", newUser)
-func (c *UsersClient) CreateUser(ctx context.Context, input *CreateUserInput) (*SuperAPIResponse, error) {
-	reqBody, err := json.Marshal(input)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling JSON: %v", err)
-	}
+func (c *UsersClient) CreateUser(ctx context.Context, input *CreateUserInput) (*UserOutput, error) {
+	ctx = superclouds.WithOperation(ctx, "users", "create")
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/users", c.config.SuperURL), bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
+	if input == nil {
+		return nil, fmt.Errorf("missing create user input")
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	if c.config.SuperToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.SuperToken)
+	if err := input.Validate(); err != nil {
+		return nil, err
 	}
 
-	resp, err := c.config.Client.Do(req)
+	var header http.Header
+	apiResponse, err := generic.Post[CreateUserInput, generic.Response[UserOutput]](generic.WithResponseHeader(ctx, &header), c.config, "/users", input)
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	var apiResponse SuperAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	apiResponse.Data.ETag = header.Get("ETag")
 
 	if apiResponse.Status != 1 {
 		return nil, fmt.Errorf("error creating user: %v", apiResponse.Message)
 	}
 
-	return &apiResponse, nil
+	c.publish("user_created", func(p EventPublisher) error {
+		return p.PublishUserCreated(ctx, &apiResponse.Data)
+	})
+
+	return &apiResponse.Data, nil
 }
 
 // DeleteUser removes a user from the organization.
@@ -250,26 +513,28 @@ func (c *UsersClient) CreateUser(ctx context.Context, input *CreateUserInput) (*
 //	}
 //	log.Println("Deleted User")
 func (c *UsersClient) DeleteUser(ctx context.Context, input *DeleteUserInput) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s/users?email=%s", c.config.SuperURL, input.Email), nil)
-	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
-	}
+	ctx = superclouds.WithOperation(ctx, "users", "delete")
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.config.SuperToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.SuperToken)
+	if input == nil {
+		return fmt.Errorf("missing delete user input")
 	}
+	ctx = superclouds.WithResource(ctx, "user", input.Email)
 
-	resp, err := c.config.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("error executing request: %v", err)
+	var opts []generic.RequestOption
+	if input.ApprovalID != "" {
+		opts = append(opts, generic.WithHeader(approvalIDHeader, input.ApprovalID))
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete user: %s", resp.Status)
+	params := url.Values{}
+	params.Add("email", input.Email)
+	if err := generic.Delete(ctx, c.config, "/users?"+params.Encode(), opts...); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
+	c.publish("user_deleted", func(p EventPublisher) error {
+		return p.PublishUserDeleted(ctx, input.Email)
+	})
+
 	return nil
 }
 
@@ -295,33 +560,22 @@ func (c *UsersClient) DeleteUser(ctx context.Context, input *DeleteUserInput) er
 //	}
 //	log.Printf("Updated User: %v", updatedUser)
 func (c *UsersClient) UpdateUser(ctx context.Context, input *UpdateUserInput) (*UserOutput, error) {
-	reqBody, err := json.Marshal(input)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling JSON: %v", err)
-	}
+	ctx = superclouds.WithOperation(ctx, "users", "update")
+	ctx = superclouds.WithResource(ctx, "user", "")
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("%s/user", c.config.SuperURL), bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
+	var opts []generic.RequestOption
+	if input != nil && input.IfMatch != "" {
+		opts = append(opts, generic.WithHeader("If-Match", input.IfMatch))
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.config.SuperToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.SuperToken)
-	}
-
-	resp, err := c.config.Client.Do(req)
+	var header http.Header
+	apiResponse, err := generic.Patch[UpdateUserInput, generic.Response[UserOutput]](generic.WithResponseHeader(ctx, &header), c.config, "/user", input, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	var output UserOutput
-	if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	apiResponse.Data.ETag = header.Get("ETag")
 
-	return &output, nil
+	return &apiResponse.Data, nil
 }
 
 // GetUser retrieves detailed information about the authenticated user.
@@ -340,30 +594,18 @@ func (c *UsersClient) UpdateUser(ctx context.Context, input *UpdateUserInput) (*
 //	    log.Fatalf("Failed to get user: %v", err)
 //	}
 //	log.Printf("Authenticated User: %v", user)
-func (c *UsersClient) GetUser(ctx context.Context) (*User, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/user", c.config.SuperURL), nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
-	}
+func (c *UsersClient) GetUser(ctx context.Context) (*UserOutput, error) {
+	ctx = superclouds.WithOperation(ctx, "users", "get")
+	ctx = superclouds.WithResource(ctx, "user", "")
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.config.SuperToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.SuperToken)
-	}
-
-	resp, err := c.config.Client.Do(req)
+	var header http.Header
+	apiResponse, err := generic.Get[generic.Response[UserOutput]](generic.WithResponseHeader(ctx, &header), c.config, "/user", nil)
 	if err != nil {
-		return nil, fmt.Errorf("error executing request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	var apiResponse SuperAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
-	user := apiResponse.Data.(User)
+	apiResponse.Data.ETag = header.Get("ETag")
 
-	return &user, nil
+	return &apiResponse.Data, nil
 }
 
 // UpdateUserRole updates the role of a user within the organization.
@@ -386,30 +628,25 @@ func (c *UsersClient) GetUser(ctx context.Context) (*User, error) {
 //	}
 //	log.Println("Updated User Role")
 func (c *UsersClient) UpdateUserRole(ctx context.Context, input *UpdateUserRoleInput) error {
-	reqBody, err := json.Marshal(input)
-	if err != nil {
-		return fmt.Errorf("error marshaling JSON: %v", err)
-	}
+	ctx = superclouds.WithOperation(ctx, "users", "update_role")
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("%s/users/role", c.config.SuperURL), bytes.NewBuffer(reqBody))
-	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+	if input == nil {
+		return fmt.Errorf("missing update user role input")
 	}
+	ctx = superclouds.WithResource(ctx, "user", input.Email)
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.config.SuperToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.SuperToken)
+	var opts []generic.RequestOption
+	if input.ApprovalID != "" {
+		opts = append(opts, generic.WithHeader(approvalIDHeader, input.ApprovalID))
 	}
 
-	resp, err := c.config.Client.Do(req)
-	if err != nil {
-		return fmt.Errorf("error executing request: %v", err)
+	if _, err := generic.Patch[UpdateUserRoleInput, struct{}](ctx, c.config, "/users/role", input, opts...); err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to update user role: %s", resp.Status)
-	}
+	c.publish("role_changed", func(p EventPublisher) error {
+		return p.PublishRoleChanged(ctx, input.Email, input.PreviousRole, input.Role)
+	})
 
 	return nil
 }
@@ -435,29 +672,269 @@ func (c *UsersClient) UpdateUserRole(ctx context.Context, input *UpdateUserRoleI
 //	}
 //	log.Println("Changed Password")
 func (c *UsersClient) ChangePassword(ctx context.Context, input *ChangePasswordInput) error {
-	reqBody, err := json.Marshal(input)
+	ctx = superclouds.WithOperation(ctx, "users", "change_password")
+
+	if _, err := generic.Patch[ChangePasswordInput, struct{}](ctx, c.config, "/change-password", input); err != nil {
+		return fmt.Errorf("failed to change password: %w", err)
+	}
+
+	return nil
+}
+
+// mergeUsersInput is the request body of the MergeUsers method.
+type mergeUsersInput struct {
+	TargetID string `json:"target_id"`
+}
+
+// MergeUsers consolidates a duplicate account into another one.
+// All resources owned by the source user are transferred to the target user,
+// after which the source user is deleted.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - sourceID: The ID of the duplicate user, which is deleted.
+// - targetID: The ID of the user that receives the resources.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := usersClient.MergeUsers(context.TODO(), "duplicate-user-id", "primary-user-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to merge users: %v", err)
+//	}
+//	log.Println("Merged Users")
+func (c *UsersClient) MergeUsers(ctx context.Context, sourceID, targetID string) error {
+	ctx = superclouds.WithOperation(ctx, "users", "merge")
+	ctx = superclouds.WithResource(ctx, "user", sourceID)
+
+	if sourceID == "" || targetID == "" {
+		return fmt.Errorf("source and target user IDs are required")
+	}
+	if sourceID == targetID {
+		return fmt.Errorf("cannot merge user %q into itself", sourceID)
+	}
+
+	input := &mergeUsersInput{TargetID: targetID}
+	if _, err := generic.Post[mergeUsersInput, struct{}](ctx, c.config, "/users/"+url.PathEscape(sourceID)+"/merge", input); err != nil {
+		return fmt.Errorf("failed to merge users: %w", err)
+	}
+
+	return nil
+}
+
+// Risk levels reported in the RiskLevel field of TrustScore.
+const (
+	RiskLevelLow    = "low"
+	RiskLevelMedium = "medium"
+	RiskLevelHigh   = "high"
+)
+
+// TrustScore is the risk engine's assessment of a user, based on their login patterns.
+// Score ranges from 0 (untrusted) to 100 (fully trusted).
+type TrustScore struct {
+	Score       float64      `json:"score"`
+	RiskLevel   string       `json:"risk_level"`
+	Factors     []RiskFactor `json:"factors"`
+	EvaluatedAt time.Time    `json:"evaluated_at"`
+}
+
+// RiskFactor is a signal that contributed to a TrustScore.
+type RiskFactor struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`
+}
+
+// GetUserTrustScore retrieves the risk engine's trust score for a user, for risk-based authentication.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - userID: The ID of the user.
+//
+// Returns:
+// - TrustScore: The user's trust score and the factors behind it.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	score, err := usersClient.GetUserTrustScore(context.TODO(), "user-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to get trust score: %v", err)
+//	}
+//	if score.RiskLevel == users.RiskLevelHigh {
+//	    log.Println("Step-up authentication required")
+//	}
+func (c *UsersClient) GetUserTrustScore(ctx context.Context, userID string) (*TrustScore, error) {
+	ctx = superclouds.WithOperation(ctx, "users", "get_trust_score")
+	ctx = superclouds.WithResource(ctx, "user", userID)
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	apiResponse, err := generic.Get[generic.Response[TrustScore]](ctx, c.config, "/users/"+url.PathEscape(userID)+"/trust-score", nil)
 	if err != nil {
-		return fmt.Errorf("error marshaling JSON: %v", err)
+		return nil, fmt.Errorf("failed to get trust score: %w", err)
+	}
+
+	return &apiResponse.Data, nil
+}
+
+// ResourceQuota is the limit and current usage of a per-user metered resource.
+type ResourceQuota struct {
+	Limit int `json:"limit"`
+	Used  int `json:"used"`
+}
+
+// Available returns how many more units of the resource the user may use, never less than zero.
+func (q ResourceQuota) Available() int {
+	return max(q.Limit-q.Used, 0)
+}
+
+// UserQuota holds the quotas of the per-user metered resources.
+type UserQuota struct {
+	APIKeys  ResourceQuota `json:"api_keys"`
+	Sessions ResourceQuota `json:"sessions"`
+	Projects ResourceQuota `json:"projects"`
+}
+
+// GetUserQuota retrieves the resource quotas of a user, such as the maximum number of API keys and sessions.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - userID: The ID of the user.
+//
+// Returns:
+// - UserQuota: The user's quotas and current usage.
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	quota, err := usersClient.GetUserQuota(context.TODO(), "user-id")
+//	if err != nil {
+//	    log.Fatalf("Failed to get user quota: %v", err)
+//	}
+//	if quota.APIKeys.Available() == 0 {
+//	    log.Println("API key limit reached")
+//	}
+func (c *UsersClient) GetUserQuota(ctx context.Context, userID string) (*UserQuota, error) {
+	ctx = superclouds.WithOperation(ctx, "users", "get_quota")
+	ctx = superclouds.WithResource(ctx, "user", userID)
+
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, fmt.Sprintf("%s/change-password", c.config.SuperURL), bytes.NewBuffer(reqBody))
+	apiResponse, err := generic.Get[generic.Response[UserQuota]](ctx, c.config, "/users/"+url.PathEscape(userID)+"/quota", nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, fmt.Errorf("failed to get user quota: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.config.SuperToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.SuperToken)
+	return &apiResponse.Data, nil
+}
+
+// Standard user attribute names. Organizations may define further attributes in their user schema.
+const (
+	AttributeDepartment = "department"
+	AttributeCostCenter = "costCenter"
+	AttributeEmployeeID = "employeeID"
+)
+
+// isAttributeName reports whether name is a well-formed attribute name:
+// a letter followed by letters, digits or underscores.
+func isAttributeName(name string) bool {
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '_'):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// validateAttributeNames checks the names of attrs against the standard
+// attribute names and the custom attributes of schema.
+func validateAttributeNames(schema *registry.UserSchema, attrs map[string]string) error {
+	known := map[string]bool{
+		AttributeDepartment: true,
+		AttributeCostCenter: true,
+		AttributeEmployeeID: true,
 	}
+	for _, attr := range schema.CustomAttributes {
+		known[attr.Name] = true
+	}
+
+	var unknown []string
+	for name := range attrs {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	fields := make([]superclouds.FieldError, len(unknown))
+	for i, name := range unknown {
+		fields[i] = superclouds.FieldError{Field: name, Message: "unknown attribute"}
+	}
+	return superclouds.ValidationError{Fields: fields}
+}
 
-	resp, err := c.config.Client.Do(req)
+// UpdateUserAttributes sets named attributes of a user, such as their department or cost center.
+// The attributes are updated atomically: either all of them are set, or none is.
+// Before sending the update, it fetches the organization's user schema and
+// rejects names that are neither standard attributes nor custom attributes of
+// the schema with a superclouds.ValidationError, one FieldError per unknown name.
+//
+// Parameters:
+// - ctx: The context for the request.
+// - userID: The ID of the user.
+// - attrs: The attributes to set, by name.
+//
+// Returns:
+// - error: Any error encountered during the request.
+//
+// Example usage:
+//
+//	err := usersClient.UpdateUserAttributes(context.TODO(), "user-id", map[string]string{
+//	    users.AttributeDepartment: "Engineering",
+//	    users.AttributeCostCenter: "CC-1234",
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to update user attributes: %v", err)
+//	}
+func (c *UsersClient) UpdateUserAttributes(ctx context.Context, userID string, attrs map[string]string) error {
+	ctx = superclouds.WithOperation(ctx, "users", "update_attributes")
+	ctx = superclouds.WithResource(ctx, "user", userID)
+
+	if userID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+	if len(attrs) == 0 {
+		return fmt.Errorf("at least one attribute is required")
+	}
+	for name := range attrs {
+		if !isAttributeName(name) {
+			return fmt.Errorf("invalid attribute name %q", name)
+		}
+	}
+
+	schema, err := registry.NewClient(c.config).GetUserSchema(ctx)
 	if err != nil {
-		return fmt.Errorf("error executing request: %v", err)
+		return fmt.Errorf("failed to update user attributes: %w", err)
+	}
+	if err := validateAttributeNames(schema, attrs); err != nil {
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to change password: %s", resp.Status)
+	if _, err := generic.Patch[map[string]string, struct{}](ctx, c.config, "/users/"+url.PathEscape(userID)+"/attributes", &attrs); err != nil {
+		return fmt.Errorf("failed to update user attributes: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,299 @@
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a UsersClient that sends its requests to a test
+// server serving handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...superclouds.Option) *users.UsersClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return newClientForURL(t, srv.URL, opts...)
+}
+
+// newClientForURL returns a UsersClient that sends its requests to baseURL.
+func newClientForURL(t *testing.T, baseURL string, opts ...superclouds.Option) *users.UsersClient {
+	t.Helper()
+	opts = append([]superclouds.Option{
+		superclouds.WithBaseURL(baseURL),
+		superclouds.WithToken("test-token"),
+		superclouds.WithHTTPClient(&http.Client{}),
+	}, opts...)
+	cfg, err := superclouds.NewConfigWithOptions("", "", opts...)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	return users.NewUsersClient(cfg)
+}
+
+func TestListUsersRoleFilter(t *testing.T) {
+	var role string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		role = r.URL.Query().Get("role")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[],"page":1,"pages":1}`))
+	})
+
+	if _, err := client.ListUsers(context.Background(), &users.ListUsersInput{Role: users.RoleAdmin}); err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if role != string(users.RoleAdmin) {
+		t.Errorf("role query parameter = %q, want %q", role, users.RoleAdmin)
+	}
+}
+
+func TestListUsersWithoutRoleFilter(t *testing.T) {
+	var query map[string][]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[],"page":1,"pages":1}`))
+	})
+
+	if _, err := client.ListUsers(context.Background(), &users.ListUsersInput{}); err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if _, ok := query["role"]; ok {
+		t.Errorf("role query parameter sent without a role filter")
+	}
+}
+
+func TestRoleNameValid(t *testing.T) {
+	for _, name := range []users.RoleName{users.RoleAdmin, users.RoleModify, users.RoleView} {
+		if !name.Valid() {
+			t.Errorf("%q.Valid() = false, want true", name)
+		}
+	}
+	if users.RoleName("OWNER").Valid() {
+		t.Errorf(`"OWNER".Valid() = true, want false`)
+	}
+}
+
+func TestUserMarshalOmitsZeroTimes(t *testing.T) {
+	data, err := json.Marshal(users.User{Id: "user-1", Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(data), "0001-01-01T00:00:00Z") {
+		t.Errorf("zero time serialized: %s", data)
+	}
+	if !strings.Contains(string(data), `"last_login_at":null`) {
+		t.Errorf("last_login_at not serialized as null: %s", data)
+	}
+}
+
+func TestUserOutputUnmarshal(t *testing.T) {
+	body := `{
+		"id": "user-1",
+		"email": "user@example.com",
+		"first_name": "John",
+		"last_name": "Doe",
+		"role": 3,
+		"status": "active",
+		"created_at": "2024-01-02T03:04:05Z",
+		"updated_at": "2024-02-03T04:05:06Z",
+		"last_login_at": "2024-03-04T05:06:07Z"
+	}`
+
+	var output users.UserOutput
+	if err := json.Unmarshal([]byte(body), &output); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	lastLoginAt := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	want := users.User{
+		Id:          "user-1",
+		Email:       "user@example.com",
+		FirstName:   "John",
+		LastName:    "Doe",
+		Role:        users.READ | users.MODIFY,
+		Status:      "active",
+		CreatedAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC),
+		LastLoginAt: &lastLoginAt,
+	}
+	got := output.User
+	if got.LastLoginAt == nil || !got.LastLoginAt.Equal(*want.LastLoginAt) {
+		t.Fatalf("LastLoginAt = %v, want %v", got.LastLoginAt, want.LastLoginAt)
+	}
+	got.LastLoginAt, want.LastLoginAt = nil, nil
+	if got != want {
+		t.Errorf("User = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateUserOmitsEmptyFields(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":1,"data":{"id":"user-1","email":"new.user@example.com"}}`))
+	})
+
+	if _, err := client.CreateUser(context.Background(), &users.CreateUserInput{Email: "new.user@example.com"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	for _, key := range []string{"first_name", "last_name", "role"} {
+		if _, ok := body[key]; ok {
+			t.Errorf("empty %s sent in request body %v", key, body)
+		}
+	}
+	if body["email"] != "new.user@example.com" {
+		t.Errorf("email = %v, want %q", body["email"], "new.user@example.com")
+	}
+}
+
+func TestCreateUserRejectsInvalidInput(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	if _, err := client.CreateUser(context.Background(), nil); err == nil {
+		t.Errorf("CreateUser(nil) returned no error")
+	}
+	if _, err := client.CreateUser(context.Background(), &users.CreateUserInput{Email: "a@example.com", Role: "OWNER"}); err == nil {
+		t.Errorf("CreateUser with an unknown role returned no error")
+	}
+}
+
+func TestUsersIteratesAllPages(t *testing.T) {
+	var requestedPages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+		var n int
+		fmt.Sscan(page, &n)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":[{"id":"user-%d"},{"id":"user-%d"}],"page":%d,"pages":3}`, 2*n-1, 2*n, n)
+	})
+
+	var ids []string
+	for user, err := range client.Users(context.Background(), &users.ListUsersInput{Size: 2}) {
+		if err != nil {
+			t.Fatalf("Users: %v", err)
+		}
+		ids = append(ids, user.Id)
+	}
+
+	want := []string{"user-1", "user-2", "user-3", "user-4", "user-5", "user-6"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("users = %v, want %v", ids, want)
+	}
+	if strings.Join(requestedPages, ",") != "1,2,3" {
+		t.Errorf("requested pages = %v, want [1 2 3]", requestedPages)
+	}
+}
+
+func TestUsersStopsWhenYieldReturnsFalse(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":"user-1"},{"id":"user-2"}],"page":1,"pages":3}`))
+	})
+
+	for range client.Users(context.Background(), &users.ListUsersInput{Size: 2}) {
+		break
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestGetUserResponseBodyTooLarge(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status":1,"data":{"id":"`)
+		io.WriteString(w, strings.Repeat("a", 11*1024*1024))
+		io.WriteString(w, `"}}`)
+	})
+
+	_, err := client.GetUser(context.Background())
+	if !errors.Is(err, superclouds.ErrResponseBodyTooLarge) {
+		t.Errorf("error = %v, want ErrResponseBodyTooLarge", err)
+	}
+}
+
+func TestUpdateUserAttributesChecksSchema(t *testing.T) {
+	var patched map[string]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /users/schema":
+			w.Write([]byte(`{"status":1,"data":{"custom_attributes":[{"name":"hireDate","type":"datetime"}]}}`))
+		case "PATCH /users/user-1/attributes":
+			if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+				t.Errorf("failed to decode request body: %v", err)
+			}
+			w.Write([]byte(`{"status":1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	attrs := map[string]string{users.AttributeDepartment: "Engineering", "hireDate": "2024-01-02"}
+	if err := client.UpdateUserAttributes(context.Background(), "user-1", attrs); err != nil {
+		t.Fatalf("UpdateUserAttributes: %v", err)
+	}
+	if len(patched) != 2 || patched["hireDate"] != "2024-01-02" {
+		t.Errorf("patched attributes = %v, want %v", patched, attrs)
+	}
+}
+
+func TestUpdateUserAttributesRejectsUnknownNames(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":1,"data":{"custom_attributes":[]}}`))
+	})
+
+	err := client.UpdateUserAttributes(context.Background(), "user-1", map[string]string{
+		users.AttributeDepartment: "Engineering",
+		"shoeSize":                "42",
+		"favoriteColor":           "blue",
+	})
+	var validationErr superclouds.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want a ValidationError", err)
+	}
+	if len(validationErr.Fields) != 2 || validationErr.Fields[0].Field != "favoriteColor" || validationErr.Fields[1].Field != "shoeSize" {
+		t.Errorf("Fields = %+v, want favoriteColor and shoeSize", validationErr.Fields)
+	}
+}
+
+func TestDeleteUserRejectsNilInput(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	if err := client.DeleteUser(context.Background(), nil); err == nil {
+		t.Errorf("DeleteUser(nil) returned no error")
+	}
+}
+
+func TestUpdateUserRoleRejectsNilInput(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	if err := client.UpdateUserRole(context.Background(), nil); err == nil {
+		t.Errorf("UpdateUserRole(nil) returned no error")
+	}
+}
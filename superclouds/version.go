@@ -0,0 +1,12 @@
+package superclouds
+
+// Version is the version of the SDK, reported to the API in the User-Agent header.
+// It is a variable so that builds can override it with the linker:
+//
+//	go build -ldflags "-X github.com/superclouds/super-sdk-go-v1/superclouds.Version=v1.0.1"
+var Version = "v1.0.0"
+
+// userAgent returns the User-Agent of the SDK, such as super-sdk-go/v1.0.0.
+func userAgent() string {
+	return "super-sdk-go/" + Version
+}
@@ -0,0 +1,48 @@
+package superclouds_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv)
+
+	if _, err := get(t, cfg, "/users/me"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if !strings.HasPrefix(userAgent, "super-sdk-go/") {
+		t.Errorf("User-Agent = %q, want prefix %q", userAgent, "super-sdk-go/")
+	}
+}
+
+func TestUserAgentAfterCallerUserAgent(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer srv.Close()
+	cfg := newTestConfig(t, srv)
+
+	req, err := cfg.NewRequest(t.Context(), http.MethodGet, cfg.URL("/users/me"), nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("User-Agent", "my-app/2.1")
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if !strings.HasPrefix(userAgent, "my-app/2.1 super-sdk-go/") {
+		t.Errorf("User-Agent = %q, want prefix %q", userAgent, "my-app/2.1 super-sdk-go/")
+	}
+}
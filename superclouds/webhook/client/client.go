@@ -0,0 +1,242 @@
+// Package client receives the webhook events that Superclouds delivers to callers.
+//
+// A Dispatcher verifies the signature of each delivery, decodes the event
+// and routes it to the EventHandler registered for its type. Handlers that
+// fail are retried with exponential backoff before the delivery is answered
+// with a 5xx status, which makes Superclouds deliver the event again later.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SignatureHeader carries the signature of a delivery: "sha256=" followed by
+// the hex-encoded HMAC-SHA256 of the request body, keyed with the webhook secret.
+const SignatureHeader = "X-Superclouds-Signature"
+
+// maxBodyBytes is the largest delivery accepted.
+const maxBodyBytes = 1 << 20
+
+// Event is a webhook event delivered by Superclouds.
+// Data holds the payload of the event, whose shape depends on Type.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// EventHandler handles the events of one type. Returning an error makes the
+// Dispatcher retry the event.
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event *Event) error
+}
+
+// EventHandlerFunc adapts a function to an EventHandler.
+type EventHandlerFunc func(ctx context.Context, event *Event) error
+
+// HandleEvent implements EventHandler.
+func (f EventHandlerFunc) HandleEvent(ctx context.Context, event *Event) error {
+	return f(ctx, event)
+}
+
+// Dispatcher routes webhook events to the EventHandler registered for their type.
+type Dispatcher struct {
+	maxRetries     int
+	initialBackoff time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string]EventHandler
+}
+
+// Option configures a Dispatcher created by NewDispatcher.
+type Option func(*Dispatcher)
+
+// WithRetry sets how many times a failing handler is retried, and the delay
+// before the first retry, which doubles after each attempt. The default is
+// 3 retries starting at 100 milliseconds.
+func WithRetry(maxRetries int, initialBackoff time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.maxRetries = maxRetries
+		d.initialBackoff = initialBackoff
+	}
+}
+
+// NewDispatcher creates a Dispatcher with no registered handlers.
+//
+// Example usage:
+//
+//	dispatcher := client.NewDispatcher()
+//	dispatcher.Register("user.created", client.EventHandlerFunc(func(ctx context.Context, event *client.Event) error {
+//	    log.Printf("User created: %s", event.Data)
+//	    return nil
+//	}))
+//	http.Handle("/webhooks/superclouds", dispatcher.Handle(webhookSecret, nil))
+func NewDispatcher(opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		maxRetries:     3,
+		initialBackoff: 100 * time.Millisecond,
+		handlers:       make(map[string]EventHandler),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Register sets the handler of the events of the given type, replacing any previous one.
+func (d *Dispatcher) Register(eventType string, handler EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = handler
+}
+
+// Handle returns an http.Handler that receives webhook deliveries signed with secret.
+// Deliveries with a missing or invalid signature are rejected with 401.
+// Events without a registered EventHandler are passed to handler, with the
+// body intact; when handler is nil, they are acknowledged and dropped.
+// A handler that responds with a 5xx status is retried like a failing EventHandler.
+// Handle panics if secret is empty, as anyone could then sign deliveries.
+func (d *Dispatcher) Handle(secret string, handler http.Handler) http.Handler {
+	if secret == "" {
+		panic("client: empty webhook secret")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if !validSignature(secret, body, r.Header.Get(SignatureHeader)) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var event Event
+		if err := json.Unmarshal(body, &event); err != nil || event.Type == "" {
+			http.Error(w, "invalid event", http.StatusBadRequest)
+			return
+		}
+
+		d.mu.RLock()
+		eventHandler, ok := d.handlers[event.Type]
+		d.mu.RUnlock()
+
+		switch {
+		case ok:
+			err := d.retry(r.Context(), func() bool {
+				return eventHandler.HandleEvent(r.Context(), &event) == nil
+			})
+			if err != nil {
+				http.Error(w, "failed to handle event", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case handler != nil:
+			var resp *bufferedResponse
+			d.retry(r.Context(), func() bool {
+				resp = newBufferedResponse()
+				req := r.Clone(r.Context())
+				req.Body = io.NopCloser(bytes.NewReader(body))
+				handler.ServeHTTP(resp, req)
+				return resp.status < http.StatusInternalServerError
+			})
+			resp.writeTo(w)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+// retry calls attempt until it succeeds, retrying up to the maximum with
+// exponential backoff. It returns ctx.Err() if ctx ends while waiting, and
+// errHandlerFailed when all attempts fail.
+func (d *Dispatcher) retry(ctx context.Context, attempt func() bool) error {
+	backoff := d.initialBackoff
+	for i := 0; ; i++ {
+		if attempt() {
+			return nil
+		}
+		if i == d.maxRetries {
+			return errHandlerFailed
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// errHandlerFailed is returned by retry when every attempt failed.
+var errHandlerFailed = errors.New("webhook handler failed")
+
+// validSignature reports whether signature is the signature of body with secret.
+// No signature is valid without a secret.
+func validSignature(secret string, body []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+	hexMAC, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(hexMAC)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// bufferedResponse is an http.ResponseWriter that holds the response until
+// it is known whether the handler should be retried.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+}
+
+// Header implements http.ResponseWriter.
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+// Write implements http.ResponseWriter.
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
+
+// writeTo sends the buffered response to w.
+func (b *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(b.status)
+	b.body.WriteTo(w)
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sign returns the SignatureHeader value of body with secret.
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver sends body to h with signature and returns the response status.
+func deliver(h http.Handler, body, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestHandleRejectsEmptySecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Handle with an empty secret did not panic")
+		}
+	}()
+	NewDispatcher().Handle("", nil)
+}
+
+func TestValidSignatureFailsClosedWithoutSecret(t *testing.T) {
+	body := []byte(`{"type":"user.created"}`)
+	if validSignature("", body, sign("", string(body))) {
+		t.Errorf("signature with an empty secret accepted")
+	}
+}
+
+func TestHandleVerifiesSignature(t *testing.T) {
+	var handled *Event
+	d := NewDispatcher(WithRetry(0, time.Millisecond))
+	d.Register("user.created", EventHandlerFunc(func(ctx context.Context, event *Event) error {
+		handled = event
+		return nil
+	}))
+	h := d.Handle("secret", nil)
+
+	body := `{"id":"evt-1","type":"user.created","data":{"id":"user-1"}}`
+	if status := deliver(h, body, sign("other-secret", body)); status != http.StatusUnauthorized {
+		t.Errorf("status with a wrong signature = %d, want %d", status, http.StatusUnauthorized)
+	}
+	if handled != nil {
+		t.Fatalf("event with a wrong signature was handled")
+	}
+
+	if status := deliver(h, body, sign("secret", body)); status != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", status, http.StatusNoContent)
+	}
+	if handled == nil || handled.ID != "evt-1" {
+		t.Errorf("handled event = %+v, want evt-1", handled)
+	}
+}
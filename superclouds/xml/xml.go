@@ -0,0 +1,78 @@
+// Package xml serialises users in XML, for enterprise middleware that cannot
+// consume JSON. It is a compatibility adapter: the API itself speaks JSON,
+// and the XML form is produced from the SDK types without changing them.
+package xml
+
+import (
+	stdxml "encoding/xml"
+	"fmt"
+	"github.com/superclouds/super-sdk-go-v1/superclouds/users"
+	"time"
+)
+
+// user is the XML form of a users.User. Timestamps are in RFC 3339 format,
+// and last_login_at is omitted for users who have never logged in.
+type user struct {
+	XMLName     stdxml.Name `xml:"user"`
+	Id          string      `xml:"id,attr"`
+	Email       string      `xml:"email"`
+	FirstName   string      `xml:"first_name"`
+	LastName    string      `xml:"last_name"`
+	Role        users.Role  `xml:"role"`
+	Status      string      `xml:"status"`
+	CreatedAt   time.Time   `xml:"created_at"`
+	UpdatedAt   time.Time   `xml:"updated_at"`
+	LastLoginAt *time.Time  `xml:"last_login_at,omitempty"`
+}
+
+// MarshalUser encodes u as a user XML element.
+//
+// Example usage:
+//
+//	data, err := xml.MarshalUser(&user)
+//	if err != nil {
+//	    log.Fatalf("Failed to marshal user: %v", err)
+//	}
+func MarshalUser(u *users.User) ([]byte, error) {
+	data, err := stdxml.Marshal(user{
+		Id:          u.Id,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Role:        u.Role,
+		Status:      u.Status,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+		LastLoginAt: u.LastLoginAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalUser decodes a user encoded by MarshalUser.
+//
+// Example usage:
+//
+//	user, err := xml.UnmarshalUser(data)
+//	if err != nil {
+//	    log.Fatalf("Failed to unmarshal user: %v", err)
+//	}
+func UnmarshalUser(data []byte) (*users.User, error) {
+	var u user
+	if err := stdxml.Unmarshal(data, &u); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+	}
+	return &users.User{
+		Id:          u.Id,
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Role:        u.Role,
+		Status:      u.Status,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+		LastLoginAt: u.LastLoginAt,
+	}, nil
+}